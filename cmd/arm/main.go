@@ -0,0 +1,74 @@
+// Command arm reaches an anchored chain toward the mouse. Press S to cycle
+// through the inverse kinematics solvers.
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"animation/kinematics"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	ScreenWidth  = 1200
+	ScreenHeight = 900
+)
+
+func main() {
+	rl.SetConfigFlags(rl.FlagVsyncHint)
+
+	rl.InitWindow(ScreenWidth, ScreenHeight, "Arm")
+	defer rl.CloseWindow()
+
+	rl.SetTargetFPS(60)
+
+	anchor := kinematics.NewVector(ScreenWidth/2, ScreenHeight-100)
+	arm := kinematics.NewChain(anchor, 8, 50, math.Pi/5)
+
+	solvers := []kinematics.Solver{
+		kinematics.Fabrik{Anchor: anchor},
+		kinematics.CCD{Anchor: anchor},
+		kinematics.DampedLeastSquares{Anchor: anchor},
+	}
+	current := 0
+	arm.SetSolver(solvers[current])
+
+	background := rl.NewColor(43, 60, 80, 255)
+	bone := rl.NewColor(255, 255, 255, 204)
+
+	for !rl.WindowShouldClose() {
+		mouse := rl.GetMousePosition()
+		target := kinematics.NewVector(float64(mouse.X), float64(mouse.Y))
+		result := arm.Resolve(target, float64(rl.GetFrameTime()))
+
+		if rl.IsKeyPressed(rl.KeyS) {
+			current = (current + 1) % len(solvers)
+			arm.SetSolver(solvers[current])
+		}
+
+		rl.BeginDrawing()
+		rl.ClearBackground(background)
+
+		joints := arm.Joints()
+		for i := 0; i < len(joints)-1; i++ {
+			rl.DrawLineEx(vec2(joints[i]), vec2(joints[i+1]), 8, bone)
+		}
+
+		for _, joint := range joints {
+			rl.DrawCircleV(vec2(joint), 10, rl.Gold)
+		}
+
+		rl.DrawCircleV(mouse, 6, rl.Red)
+		rl.DrawFPS(10, 10)
+		info := fmt.Sprintf("%T  iterations: %2d  error: %6.2f", solvers[current], result.Iterations, result.Error)
+		rl.DrawText(info, 10, ScreenHeight-30, 20, rl.White)
+
+		rl.EndDrawing()
+	}
+}
+
+func vec2(v kinematics.Vector) rl.Vector2 {
+	return rl.Vector2{X: float32(v.X), Y: float32(v.Y)}
+}
@@ -0,0 +1,62 @@
+// Command bench measures how many chains can be resolved per frame, one
+// Chain at a time against a Batch, and prints testing.B style results.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"testing"
+
+	"animation/kinematics"
+)
+
+const FrameTime = 1.0 / 60
+
+func main() {
+	chains := flag.Int("chains", 10000, "chains resolved per frame")
+	joints := flag.Int("joints", 16, "joints per chain")
+	flag.Parse()
+
+	specs := make([]kinematics.JointSpec, *joints)
+	for i := range specs {
+		specs[i] = kinematics.SymmetricJoint(20, math.Pi/8)
+	}
+
+	targets := make([]kinematics.Vector, *chains)
+	for i := range targets {
+		angle := float64(i) * 0.1
+		targets[i] = kinematics.NewVector(500+400*math.Cos(angle), 500+400*math.Sin(angle))
+	}
+
+	report("Chain", *chains, testing.Benchmark(func(b *testing.B) {
+		crowd := make([]*kinematics.Chain, *chains)
+		for i := range crowd {
+			crowd[i] = kinematics.NewChainFromSpecs(kinematics.Vector{}, specs)
+		}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			for i, c := range crowd {
+				c.Resolve(targets[i], FrameTime)
+			}
+		}
+	}))
+
+	report("Batch", *chains, testing.Benchmark(func(b *testing.B) {
+		crowd := kinematics.NewBatch(*chains, kinematics.Vector{}, specs)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			crowd.Resolve(targets, FrameTime)
+		}
+	}))
+}
+
+// report prints one frame of chains per op, and how much of a 60 FPS frame it takes
+func report(name string, chains int, r testing.BenchmarkResult) {
+	frame := float64(r.NsPerOp()) / 1e9 / FrameTime
+	fmt.Printf("%-6s %s %s  %.1f%% of a frame for %d chains\n", name, r, r.MemString(), frame*100, chains)
+}
@@ -0,0 +1,116 @@
+// Command cloth hangs a sheet of particles from its top edge using the
+// position based dynamics System. The nearest particle follows the mouse while
+// the left button is held, and the sheet drapes over a ball.
+package main
+
+import (
+	"animation/kinematics"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	ScreenWidth  = 1200
+	ScreenHeight = 900
+
+	Columns = 30
+	Rows    = 20
+	Spacing = 20.0
+)
+
+func main() {
+	rl.SetConfigFlags(rl.FlagVsyncHint)
+
+	rl.InitWindow(ScreenWidth, ScreenHeight, "Cloth")
+	defer rl.CloseWindow()
+
+	rl.SetTargetFPS(60)
+
+	ball := kinematics.Circle{Center: kinematics.NewVector(ScreenWidth/2, 550), Radius: 90}
+	cloth := kinematics.NewSystem()
+	cloth.Drag = 1
+
+	left := (ScreenWidth - (Columns-1)*Spacing) / 2
+	for y := 0; y < Rows; y++ {
+		for x := 0; x < Columns; x++ {
+			pos := kinematics.NewVector(left+float64(x)*Spacing, 100+float64(y)*Spacing)
+
+			// Every fifth particle of the top edge hangs from a nail
+			invMass := 1.0
+			if y == 0 && x%5 == 0 {
+				invMass = 0
+			}
+
+			i := cloth.AddParticle(pos, invMass)
+			cloth.Add(&kinematics.Collision{P: i, Radius: 4, Collider: ball})
+		}
+	}
+
+	// Threads hold neighbours no further than Spacing apart but let them bunch up
+	for y := 0; y < Rows; y++ {
+		for x := 0; x < Columns; x++ {
+			i := y*Columns + x
+			if x > 0 {
+				cloth.Add(&kinematics.Distance{A: i - 1, B: i, Max: Spacing})
+			}
+			if y > 0 {
+				cloth.Add(&kinematics.Distance{A: i - Columns, B: i, Max: Spacing})
+			}
+		}
+	}
+
+	var grab *kinematics.Pin
+	grabMass := 0.0
+
+	background := rl.NewColor(43, 60, 80, 255)
+	thread := rl.NewColor(230, 220, 200, 255)
+
+	for !rl.WindowShouldClose() {
+		mouse := rl.GetMousePosition()
+		pos := kinematics.NewVector(float64(mouse.X), float64(mouse.Y))
+		particles := cloth.Particles()
+
+		switch {
+		case rl.IsMouseButtonPressed(rl.MouseButtonLeft):
+			nearest := 0
+			for i, p := range particles {
+				if p.Pos.DistanceSquared(pos) < particles[nearest].Pos.DistanceSquared(pos) {
+					nearest = i
+				}
+			}
+
+			grab = &kinematics.Pin{P: nearest, Pos: pos}
+			grabMass = particles[nearest].InvMass
+			particles[nearest].InvMass = 0
+			cloth.Add(grab)
+		case rl.IsMouseButtonDown(rl.MouseButtonLeft) && grab != nil:
+			grab.Pos = pos
+		case grab != nil:
+			// Let go by handing the particle back to the other constraints
+			particles[grab.P].InvMass = grabMass
+			cloth.Remove(grab)
+			grab = nil
+		}
+
+		cloth.Step(float64(rl.GetFrameTime()))
+
+		rl.BeginDrawing()
+		rl.ClearBackground(background)
+
+		rl.DrawCircleV(vec2(ball.Center), float32(ball.Radius)-4, rl.DarkGray)
+
+		for _, c := range cloth.Constraints() {
+			if d, ok := c.(*kinematics.Distance); ok {
+				rl.DrawLineV(vec2(particles[d.A].Pos), vec2(particles[d.B].Pos), thread)
+			}
+		}
+
+		rl.DrawFPS(10, 10)
+
+		rl.EndDrawing()
+	}
+}
+
+func vec2(v kinematics.Vector) rl.Vector2 {
+	return rl.Vector2{X: float32(v.X), Y: float32(v.Y)}
+}
@@ -0,0 +1,81 @@
+// Command lizard walks a procedural lizard toward the mouse. S toggles spring
+// joints in the spine.
+package main
+
+import (
+	"animation/creature"
+	"animation/kinematics"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	ScreenWidth  = 1600
+	ScreenHeight = 1200
+
+	SpineStiffness = 30
+	SpineDamping   = 10
+)
+
+func main() {
+	rl.SetConfigFlags(rl.FlagVsyncHint)
+
+	rl.InitWindow(ScreenWidth, ScreenHeight, "Lizard")
+	defer rl.CloseWindow()
+
+	rl.SetTargetFPS(60)
+
+	lizard := creature.NewLizard(kinematics.NewVector(ScreenWidth/2, ScreenHeight/2), 0.6)
+
+	background := rl.NewColor(40, 44, 52, 255)
+	skin := rl.NewColor(82, 121, 111, 255)
+	outline := rl.NewColor(255, 255, 255, 255)
+
+	springs := false
+
+	for !rl.WindowShouldClose() {
+		if rl.IsKeyPressed(rl.KeyS) {
+			springs = !springs
+			if springs {
+				lizard.Spine.SetSprings(SpineStiffness, SpineDamping)
+			} else {
+				lizard.Spine.SetSprings(0, 0)
+			}
+		}
+
+		mouse := rl.GetMousePosition()
+		lizard.Update(kinematics.NewVector(float64(mouse.X), float64(mouse.Y)), float64(rl.GetFrameTime()))
+
+		rl.BeginDrawing()
+		rl.ClearBackground(background)
+
+		for _, leg := range lizard.Legs {
+			joints := leg.Chain.Joints()
+			for i := 0; i < len(joints)-1; i++ {
+				rl.DrawLineEx(vec2(joints[i]), vec2(joints[i+1]), float32(lizard.Width(12)*2), skin)
+			}
+
+			foot := outline
+			if leg.Stepping() {
+				foot = rl.Gold
+			}
+			rl.DrawCircleV(vec2(joints[0]), float32(lizard.Width(11)), foot)
+		}
+
+		spine := lizard.Spine.Joints()
+		for i := len(spine) - 1; i >= 0; i-- {
+			rl.DrawCircleV(vec2(spine[i]), float32(lizard.Width(i))+2, outline)
+		}
+		for i := len(spine) - 1; i >= 0; i-- {
+			rl.DrawCircleV(vec2(spine[i]), float32(lizard.Width(i)), skin)
+		}
+
+		rl.DrawFPS(10, 10)
+
+		rl.EndDrawing()
+	}
+}
+
+func vec2(v kinematics.Vector) rl.Vector2 {
+	return rl.Vector2{X: float32(v.X), Y: float32(v.Y)}
+}
@@ -0,0 +1,89 @@
+// Command rope hangs verlet chains from the ceiling. The rope follows the
+// mouse while the left button is held and swings free when it is released.
+package main
+
+import (
+	"math"
+
+	"animation/kinematics"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	ScreenWidth  = 1200
+	ScreenHeight = 900
+)
+
+func main() {
+	rl.SetConfigFlags(rl.FlagVsyncHint)
+
+	rl.InitWindow(ScreenWidth, ScreenHeight, "Rope")
+	defer rl.CloseWindow()
+
+	rl.SetTargetFPS(60)
+
+	var vines []*kinematics.VerletChain
+	for i := 0; i < 5; i++ {
+		top := kinematics.NewVector(float64(200+i*200), 0)
+		vine := kinematics.NewVerletChain(kinematics.NewChain(top, 12+i*2, 25, math.Pi/8))
+		vine.Pin(0, top)
+		vines = append(vines, vine)
+	}
+
+	rope := kinematics.NewVerletChain(kinematics.NewChain(kinematics.NewVector(ScreenWidth/2, 200), 20, 20, math.Pi/3))
+	rope.Drag = 1.5
+
+	background := rl.NewColor(43, 60, 80, 255)
+	vineColor := rl.NewColor(90, 170, 90, 255)
+
+	for !rl.WindowShouldClose() {
+		mouse := rl.GetMousePosition()
+		if rl.IsMouseButtonDown(rl.MouseButtonLeft) {
+			rope.Pin(0, kinematics.NewVector(float64(mouse.X), float64(mouse.Y)))
+		} else {
+			rope.Unpin(0)
+		}
+
+		// Brush the vines aside when the rope passes through them
+		for _, vine := range vines {
+			for i, joint := range vine.Joints() {
+				if joint.Distance(rope.Joints()[0]) < 60 {
+					vine.ApplyImpulse(i, rope.Velocity(0).Multiply(0.1))
+				}
+			}
+		}
+
+		dt := float64(rl.GetFrameTime())
+		for _, vine := range vines {
+			vine.Step(dt)
+		}
+		rope.Step(dt)
+
+		rl.BeginDrawing()
+		rl.ClearBackground(background)
+
+		for _, vine := range vines {
+			drawChain(vine.Joints(), 6, vineColor)
+		}
+		drawChain(rope.Joints(), 8, rl.Gold)
+
+		rl.DrawFPS(10, 10)
+
+		rl.EndDrawing()
+	}
+}
+
+func drawChain(joints []kinematics.Vector, thickness float32, color rl.Color) {
+	for i := 0; i < len(joints)-1; i++ {
+		rl.DrawLineEx(vec2(joints[i]), vec2(joints[i+1]), thickness, color)
+	}
+
+	for _, joint := range joints {
+		rl.DrawCircleV(vec2(joint), thickness, color)
+	}
+}
+
+func vec2(v kinematics.Vector) rl.Vector2 {
+	return rl.Vector2{X: float32(v.X), Y: float32(v.Y)}
+}
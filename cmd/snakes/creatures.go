@@ -0,0 +1,92 @@
+package main
+
+import (
+	"animation/creature"
+	"animation/kinematics"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+var (
+	fishBody    = rl.NewColor(58, 124, 165, 255)
+	fishFin     = rl.NewColor(129, 195, 215, 255)
+	fishOutline = rl.NewColor(255, 255, 255, 255)
+	jellyBody   = rl.NewColor(205, 140, 220, 160)
+)
+
+func drawCritters() {
+	for _, c := range world.Critters() {
+		switch animal := c.Creature.(type) {
+		case *creature.Fish:
+			drawFish(animal)
+		case *creature.Jelly:
+			drawBlob(animal.Body, jellyBody)
+		}
+	}
+}
+
+func drawFish(f *creature.Fish) {
+	for _, fin := range f.PectoralFins() {
+		drawEllipse(fin, fishFin)
+	}
+
+	for _, fin := range f.VentralFins() {
+		drawEllipse(fin, fishFin)
+	}
+
+	drawRibbon(f.CaudalFin(), fishFin)
+	drawRibbon(f.Body(), fishBody)
+	drawRibbon(f.DorsalFin(), fishFin)
+
+	eyes, radius := f.Eyes()
+	for _, eye := range eyes {
+		rl.DrawCircleV(vec2(eye), float32(radius), fishOutline)
+	}
+}
+
+// drawRibbon fills the ribbon one quad at a time and outlines it
+func drawRibbon(r creature.Ribbon, color rl.Color) {
+	for i := 0; i < len(r.A)-1; i++ {
+		drawTriangle(r.A[i], r.B[i], r.B[i+1], color)
+		drawTriangle(r.A[i], r.B[i+1], r.A[i+1], color)
+	}
+
+	drawOutline(r.Outline(), fishOutline)
+}
+
+func drawEllipse(e creature.Ellipse, color rl.Color) {
+	points := e.Outline(24)
+	for i := range points {
+		drawTriangle(e.Center, points[i], points[(i+1)%len(points)], color)
+	}
+
+	drawOutline(points, fishOutline)
+}
+
+// drawBlob fills a soft ring as a fan around its center and outlines it
+func drawBlob(r *kinematics.Ring, color rl.Color) {
+	center := r.Center()
+	points := r.Joints()
+	for i := range points {
+		drawTriangle(center, points[i], points[(i+1)%len(points)], color)
+	}
+
+	drawOutline(points, fishOutline)
+}
+
+// drawTriangle fills a triangle given in either winding order
+func drawTriangle(a, b, c kinematics.Vector, color rl.Color) {
+	// raylib only fills triangles that are counter-clockwise on screen, which
+	// with y pointing down is a negative cross product
+	if b.Subtract(a).Cross(c.Subtract(a)) > 0 {
+		b, c = c, b
+	}
+
+	rl.DrawTriangle(vec2(a), vec2(b), vec2(c), color)
+}
+
+func drawOutline(points []kinematics.Vector, color rl.Color) {
+	for i := range points {
+		rl.DrawLineEx(vec2(points[i]), vec2(points[(i+1)%len(points)]), 2, color)
+	}
+}
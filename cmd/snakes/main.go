@@ -0,0 +1,216 @@
+// Command snakes draws the snake world from package sim with raylib.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+	"time"
+
+	"animation/kinematics"
+	"animation/sim"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	ScreenWidth  = 1600
+	ScreenHeight = 1200
+)
+
+var (
+	background = rl.NewColor(43, 60, 80, 255)
+	world      *sim.World
+)
+
+func main() {
+	seed := flag.Uint64("seed", uint64(time.Now().UnixNano()), "random seed for the world")
+	snakes := flag.Int("snakes", sim.NumSnakes, "snakes in the world, e.g. 2000 for a stress run")
+	workers := flag.Int("workers", runtime.GOMAXPROCS(0), "goroutines sharing the snake updates")
+	flag.Parse()
+
+	world = sim.NewWorld(ScreenWidth, ScreenHeight, *seed)
+	world.SetWorkers(*workers)
+	if *snakes != sim.NumSnakes {
+		world.SetPopulation(*snakes)
+		world.Reset()
+	}
+
+	rl.SetConfigFlags(rl.FlagVsyncHint)
+
+	rl.InitWindow(ScreenWidth, ScreenHeight, "Snakes")
+	defer rl.CloseWindow()
+
+	rl.SetTargetFPS(60)
+
+	pause := false
+	for !rl.WindowShouldClose() {
+
+		if !pause {
+			world.Step(float64(rl.GetFrameTime()))
+		}
+
+		rl.BeginDrawing()
+		rl.ClearBackground(background)
+
+		drawFood()
+		drawCritters()
+		drawSnakes()
+		status()
+
+		rl.DrawFPS(10, 10)
+
+		rl.EndDrawing()
+
+		if rl.IsKeyPressed(rl.KeyR) {
+			world.Reset()
+		}
+
+		if rl.IsKeyPressed(rl.KeyP) || rl.IsKeyPressed(rl.KeySpace) {
+			pause = !pause
+		}
+	}
+}
+
+func status() {
+	statusJoints(ScreenHeight - 55)
+	statusLinkSize(ScreenHeight - 25)
+}
+
+func statusLinkSize(y int32) {
+	sb := strings.Builder{}
+
+	sb.WriteString("Factor:  ")
+	for _, s := range world.Snakes() {
+		sb.WriteString(fmt.Sprintf("[%s]: %3d  ", s.Name, int32(s.BodyFactor*100)))
+	}
+
+	rl.DrawText(sb.String(), 10, y, 20, rl.White)
+}
+
+func statusJoints(y int32) {
+	sb := strings.Builder{}
+
+	sb.WriteString("Joints:  ")
+	for _, s := range world.Snakes() {
+		sb.WriteString(fmt.Sprintf("[%s]: %3d  ", s.Name, s.Chain.Len()))
+	}
+
+	rl.DrawText(sb.String(), 10, y, 20, rl.White)
+}
+
+func vec2(v kinematics.Vector) rl.Vector2 {
+	return rl.Vector2{X: float32(v.X), Y: float32(v.Y)}
+}
+
+func rlColor(c sim.Color) rl.Color {
+	return rl.NewColor(c.R, c.G, c.B, c.A)
+}
+
+// collisionColor maps the last collision of a snake to its highlight color
+func collisionColor(c sim.Collision) rl.Color {
+	switch c {
+	case sim.CollisionAte:
+		return rl.Gold
+	case sim.CollisionWon:
+		return rl.NewColor(0, 255, 0, 153)
+	case sim.CollisionLost:
+		return rl.NewColor(255, 0, 0, 153)
+	}
+
+	return rl.Blank
+}
+
+func drawFood() {
+	drawBlob(world.Food().Body.Body, rl.Gold)
+}
+
+func drawSnakes() {
+	for _, s := range world.Snakes() {
+		color := rlColor(s.Color)
+		c := s.Chain
+		const (
+			lineThickness = 6
+		)
+
+		width := func(i int) float32 {
+			return float32(sim.BodyWidth(i, s.BodyFactor))
+		}
+
+		// skin
+		color.A = 102
+		for i, joint := range c.Joints() {
+			if i == 0 {
+				continue
+			}
+			size := width(i)
+			rl.DrawCircle(int32(joint.X), int32(joint.Y), size, color)
+		}
+
+		// more body
+		spine := rl.NewColor(255, 255, 255, 153)
+		for i, joint := range c.Joints() {
+			size := width(i) * 1.1
+			rotation := float32(c.Angles()[i]) * 180 / math.Pi
+			rec := rl.Rectangle{
+				X:      float32(joint.X),
+				Y:      float32(joint.Y),
+				Width:  size,
+				Height: size,
+			}
+
+			origin := rl.Vector2{X: size / 2, Y: size / 2}
+			rl.DrawRectanglePro(rec, origin, rotation, color)
+		}
+
+		// drawSnakes head
+		joint := c.Head()
+		b := width(0)
+		color.A = 204
+		rl.DrawCircle(int32(joint.X), int32(joint.Y), b, color)
+
+		// Show visual indicator when snake is in slow state after eating food
+		if s.AteTime > 0 && world.Time()-s.AteTime < sim.Digestion {
+			slowColor := rl.NewColor(0, 191, 255, 153) // Deep Sky Blue with transparency
+			rl.DrawCircle(int32(joint.X), int32(joint.Y), b*1.4, slowColor)
+		}
+
+		if s.CollisionTime > 0 && world.Time()-s.CollisionTime < sim.CollisionTime {
+			rl.DrawCircle(int32(joint.X), int32(joint.Y), b*1.5, collisionColor(s.Collision))
+		}
+
+		// spinal column
+		for i, joint := range c.Joints() {
+			size := width(i) / 2.0
+			rotation := float32(c.Angles()[i]) * 180 / math.Pi
+			rec := rl.Rectangle{
+				X:      float32(joint.X),
+				Y:      float32(joint.Y),
+				Width:  size,
+				Height: size,
+			}
+
+			origin := rl.Vector2{X: size / 2, Y: size / 2}
+			rl.DrawRectanglePro(rec, origin, rotation, spine)
+		}
+
+		//// skin
+		//color.A = 128
+		//for i, joint := range c.Joints() {
+		//	b := width(i)
+		//	rl.DrawCircle(int32(joint.X), int32(joint.Y), b, color)
+		//}
+
+		// drawSnakes spine
+		for i := 0; i < c.Len()-1; i++ {
+			startJoint := c.Joints()[i]
+			endJoint := c.Joints()[i+1]
+
+			rl.DrawLineEx(vec2(startJoint), vec2(endJoint), lineThickness, spine)
+		}
+
+		rl.DrawText(s.Name, int32(joint.X), int32(joint.Y), 32, rl.Black)
+	}
+}
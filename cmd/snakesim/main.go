@@ -0,0 +1,42 @@
+// Command snakesim steps the snake world from package sim without a window,
+// for balance and stress runs on machines with no display. It does not
+// import raylib, so it builds anywhere Go does.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+	"time"
+
+	"animation/sim"
+)
+
+const (
+	WorldWidth  = 1600
+	WorldHeight = 1200
+
+	// FrameTime is the fixed step, matching the 60 FPS of cmd/snakes
+	FrameTime = 1.0 / 60
+)
+
+func main() {
+	minutes := flag.Float64("minutes", 10, "simulated minutes to run")
+	seed := flag.Uint64("seed", uint64(time.Now().UnixNano()), "random seed for the world")
+	snakes := flag.Int("snakes", sim.NumSnakes, "snakes in the world, e.g. 2000 for a stress run")
+	workers := flag.Int("workers", runtime.GOMAXPROCS(0), "goroutines sharing the snake updates")
+	flag.Parse()
+
+	world := sim.NewWorld(WorldWidth, WorldHeight, *seed)
+	world.SetWorkers(*workers)
+	if *snakes != sim.NumSnakes {
+		world.SetPopulation(*snakes)
+		world.Reset()
+	}
+
+	for world.Time() < *minutes*60 {
+		world.Step(FrameTime)
+	}
+
+	fmt.Printf("Simulated %0.1f minutes, %d snakes left\n", world.Time()/60, len(world.Snakes()))
+}
@@ -0,0 +1,46 @@
+// Command vectordemo prints a walkthrough of the kinematics vector helpers.
+package main
+
+import (
+	"fmt"
+
+	"animation/kinematics"
+)
+
+func main() {
+	// Example usage
+	fmt.Println("Vector Animation Utilities Demo")
+	fmt.Println("=================================")
+
+	// Create vectors
+	v1 := kinematics.NewVector(3, 4)
+	v2 := kinematics.NewVector(1, 2)
+
+	fmt.Printf("v1: %s\n", v1)
+	fmt.Printf("v2: %s\n", v2)
+	fmt.Printf("v1 + v2: %s\n", v1.Add(v2))
+	fmt.Printf("v1 - v2: %s\n", v1.Subtract(v2))
+	fmt.Printf("v1 magnitude: %.2f\n", v1.Magnitude())
+	fmt.Printf("Distance between v1 and v2: %.2f\n", v1.Distance(v2))
+	fmt.Printf("v1 normalized: %s\n", v1.Normalize())
+
+	fmt.Println("\nDistance Constraint Demo:")
+	fmt.Println("========================")
+
+	p1 := kinematics.NewVector(0, 0)
+	p2 := kinematics.NewVector(10, 0)
+
+	fmt.Printf("Original points: p1=%s, p2=%s, distance=%.2f\n", p1, p2, p1.Distance(p2))
+
+	// Constrain distance to be between 3 and 6 units
+	constrained := kinematics.ConstrainDistance(p1, p2, 3, 6)
+	fmt.Printf("Constrained p2: %s, new distance=%.2f\n", constrained, p1.Distance(constrained))
+
+	// Symmetric constraint example
+	p3 := kinematics.NewVector(-2, 0)
+	p4 := kinematics.NewVector(2, 0)
+	fmt.Printf("\nSymmetric constraint: p3=%s, p4=%s, distance=%.2f\n", p3, p4, p3.Distance(p4))
+
+	new_p3, new_p4 := kinematics.ConstrainDistanceSymmetric(p3, p4, 6, 10)
+	fmt.Printf("After symmetric constraint: p3=%s, p4=%s, distance=%.2f\n", new_p3, new_p4, new_p3.Distance(new_p4))
+}
@@ -0,0 +1,97 @@
+package creature
+
+import (
+	"math"
+
+	"animation/kinematics"
+)
+
+// Amoeba is a soft ring that creeps toward its target by reaching out with the
+// side of its body that faces it
+type Amoeba struct {
+	Body  *kinematics.Ring
+	Speed float64 // How fast it creeps, in pixels per second
+}
+
+// NewAmoeba creates an amoeba of the given radius centered on origin
+func NewAmoeba(origin kinematics.Vector, radius float64) *Amoeba {
+	body := kinematics.NewRing(origin, 16, radius)
+	body.Gravity = kinematics.Vector{}
+	body.Drag = 4
+	body.Pressure = 0.2
+
+	return &Amoeba{Body: body, Speed: 20}
+}
+
+// Update pulls the joints facing target toward it, the rest trail behind
+func (a *Amoeba) Update(target kinematics.Vector, dt float64) {
+	dir := target.Subtract(a.Body.Center())
+	if dir.MagnitudeSquared() > 1 {
+		dir = dir.Normalize()
+
+		// Drag settles the body at Speed, the leading edge pulls harder
+		for i := 0; i < a.Body.Len(); i++ {
+			reach := 0.5 + math.Max(0, a.Body.Normal(i).Dot(dir))
+			a.Body.ApplyForce(i, dir.Multiply(a.Speed*a.Body.Drag*reach))
+		}
+	}
+
+	a.Body.Step(dt)
+}
+
+// Head returns the center of the body
+func (a *Amoeba) Head() kinematics.Vector {
+	return a.Body.Center()
+}
+
+// Jelly is a soft bell that swims in pulses. It squeezes its bell and pushes
+// itself toward its target, then relaxes and drifts.
+type Jelly struct {
+	Body  *kinematics.Ring
+	Pulse float64 // Pulses per second
+
+	radius float64
+	phase  float64
+}
+
+// NewJelly creates a jelly of the given radius centered on origin
+func NewJelly(origin kinematics.Vector, radius float64) *Jelly {
+	body := kinematics.NewRing(origin, 20, radius)
+	body.Gravity = kinematics.Vector{}
+	body.Drag = 2
+	body.Pressure = 0.5
+
+	return &Jelly{Body: body, Pulse: 0.8, radius: radius}
+}
+
+// Update squeezes or relaxes the bell and, while squeezing, swims toward target
+func (j *Jelly) Update(target kinematics.Vector, dt float64) {
+	j.phase = math.Mod(j.phase+dt*j.Pulse, 1)
+
+	// A quick squeeze over the first third of the pulse, a slow release after
+	squeeze := 0.0
+	if j.phase < 1.0/3 {
+		squeeze = math.Sin(j.phase * 3 * math.Pi)
+	}
+	j.Body.SetRadius(j.radius * (1 - 0.2*squeeze))
+
+	dir := target.Subtract(j.Body.Center())
+	if squeeze > 0 && dir.MagnitudeSquared() > 1 {
+		thrust := dir.Normalize().Multiply(600 * squeeze)
+		for i := 0; i < j.Body.Len(); i++ {
+			j.Body.ApplyForce(i, thrust)
+		}
+	}
+
+	j.Body.Step(dt)
+}
+
+// Head returns the center of the bell
+func (j *Jelly) Head() kinematics.Vector {
+	return j.Body.Center()
+}
+
+// Radius returns the radius of the relaxed bell
+func (j *Jelly) Radius() float64 {
+	return j.radius
+}
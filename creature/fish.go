@@ -0,0 +1,141 @@
+package creature
+
+import (
+	"math"
+
+	"animation/kinematics"
+)
+
+// fishWidths is the half width of the fish body at each spine joint, head
+// first. The last two spine joints carry the caudal fin only.
+var fishWidths = []float64{68, 81, 84, 83, 77, 64, 51, 38, 32, 19}
+
+// Fish is a spine chain drawn with a body outline, pectoral, ventral, dorsal
+// and caudal fins. The fins swing with the bend of the spine.
+type Fish struct {
+	Spine *kinematics.Chain
+
+	scale float64
+}
+
+// NewFish creates a fish with its head at origin. A scale of 1 matches the
+// size of the reference demo.
+func NewFish(origin kinematics.Vector, scale float64) *Fish {
+	spine := kinematics.NewChain(origin, 12, int(64*scale), math.Pi/8)
+
+	// Springs swing the tail back after a turn instead of leaving it kinked
+	spine.SetSprings(30, 10)
+
+	return &Fish{Spine: spine, scale: scale}
+}
+
+// Update moves the head toward target and lets the body follow
+func (f *Fish) Update(target kinematics.Vector, dt float64) {
+	f.Spine.Resolve(target, dt)
+}
+
+// Head returns the position of the head
+func (f *Fish) Head() kinematics.Vector {
+	return f.Spine.Head()
+}
+
+// Width returns the half width of the body at spine joint i
+func (f *Fish) Width(i int) float64 {
+	return fishWidths[i] * f.scale
+}
+
+// Body returns the outline of the body from the snout to the base of the tail
+func (f *Fish) Body() Ribbon {
+	var body Ribbon
+
+	snout := f.bodyPoint(0, 0, 0)
+	body.A = append(body.A, snout)
+	body.B = append(body.B, snout)
+
+	// Round off the head before running down the sides
+	for _, angle := range []float64{math.Pi / 4, math.Pi / 2} {
+		body.A = append(body.A, f.bodyPoint(0, angle, 0))
+		body.B = append(body.B, f.bodyPoint(0, -angle, 0))
+	}
+
+	for i := 1; i < len(fishWidths); i++ {
+		body.A = append(body.A, f.bodyPoint(i, math.Pi/2, 0))
+		body.B = append(body.B, f.bodyPoint(i, -math.Pi/2, 0))
+	}
+
+	return body
+}
+
+// PectoralFins returns the large fins behind the head, right fin first
+func (f *Fish) PectoralFins() []Ellipse {
+	a := f.Spine.Angles()
+	return []Ellipse{
+		{Center: f.bodyPoint(3, math.Pi/3, 0), Angle: a[2] - math.Pi/4, Width: 80 * f.scale, Height: 32 * f.scale},
+		{Center: f.bodyPoint(3, -math.Pi/3, 0), Angle: a[2] + math.Pi/4, Width: 80 * f.scale, Height: 32 * f.scale},
+	}
+}
+
+// VentralFins returns the small fins near the tail, right fin first
+func (f *Fish) VentralFins() []Ellipse {
+	a := f.Spine.Angles()
+	return []Ellipse{
+		{Center: f.bodyPoint(7, math.Pi/2, 0), Angle: a[6] - math.Pi/4, Width: 48 * f.scale, Height: 16 * f.scale},
+		{Center: f.bodyPoint(7, -math.Pi/2, 0), Angle: a[6] + math.Pi/4, Width: 48 * f.scale, Height: 16 * f.scale},
+	}
+}
+
+// CaudalFin returns the tail fin, which fans out as the body bends
+func (f *Fish) CaudalFin() Ribbon {
+	j := f.Spine.Joints()
+	a := f.Spine.Angles()
+	headToTail := f.headToTail()
+
+	var fin Ribbon
+	for i := 8; i < len(j); i++ {
+		bottom := 1.5 * headToTail * float64((i-8)*(i-8)) * f.scale
+		top := math.Max(-13, math.Min(13, headToTail*6)) * f.scale
+
+		fin.A = append(fin.A, j[i].Add(kinematics.FromAngle(a[i]-math.Pi/2).Multiply(bottom)))
+		fin.B = append(fin.B, j[i].Add(kinematics.FromAngle(a[i]+math.Pi/2).Multiply(top)))
+	}
+
+	return fin
+}
+
+// DorsalFin returns the fin along the back, which leans out as the body bends
+func (f *Fish) DorsalFin() Ribbon {
+	j := f.Spine.Joints()
+	a := f.Spine.Angles()
+	headToMid1 := kinematics.RelativeAngleDiff(a[0], a[6])
+	headToMid2 := kinematics.RelativeAngleDiff(a[0], a[7])
+	lean := []float64{0, headToMid1 * 16, headToMid2 * 16, 0}
+
+	var fin Ribbon
+	for k, i := range []int{4, 5, 6, 7} {
+		fin.A = append(fin.A, j[i])
+		fin.B = append(fin.B, j[i].Add(kinematics.FromAngle(a[i]+math.Pi/2).Multiply(lean[k]*f.scale)))
+	}
+
+	return fin
+}
+
+// Eyes returns the centers of the eyes and their radius
+func (f *Fish) Eyes() ([]kinematics.Vector, float64) {
+	return []kinematics.Vector{
+		f.bodyPoint(0, math.Pi/2, -18*f.scale),
+		f.bodyPoint(0, -math.Pi/2, -18*f.scale),
+	}, 12 * f.scale
+}
+
+// headToTail is how far the spine bends from head to tail
+func (f *Fish) headToTail() float64 {
+	a := f.Spine.Angles()
+	return kinematics.RelativeAngleDiff(a[0], a[6]) + kinematics.RelativeAngleDiff(a[6], a[len(a)-1])
+}
+
+// bodyPoint returns the point on the body edge at spine joint i, at angle
+// from the spine heading and offset beyond the body width
+func (f *Fish) bodyPoint(i int, angle, offset float64) kinematics.Vector {
+	heading := f.Spine.Angles()[i] + angle
+	return f.Spine.Joints()[i].Add(kinematics.FromAngle(heading).Multiply(f.Width(i) + offset))
+}
@@ -0,0 +1,61 @@
+package creature
+
+import (
+	"math"
+	"testing"
+
+	"animation/kinematics"
+)
+
+// finite reports whether every point is a real position
+func finite(points ...kinematics.Vector) bool {
+	for _, p := range points {
+		if math.IsNaN(p.X) || math.IsNaN(p.Y) || math.IsInf(p.X, 0) || math.IsInf(p.Y, 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestFishSwims(t *testing.T) {
+	for _, scale := range []float64{0.5, 1, 2} {
+		f := NewFish(kinematics.NewVector(400, 300), scale)
+
+		// The outline and fins pick out fixed spine joints, the last of them
+		// the end of the body outline
+		if f.Spine.Len() < len(fishWidths) {
+			t.Fatalf("scale %v: spine has %d joints, want at least %d", scale, f.Spine.Len(), len(fishWidths))
+		}
+
+		for frame := 1; frame <= 600; frame++ {
+			at := float64(frame) / 60
+			f.Update(kinematics.NewVector(400+250*math.Cos(at), 300+180*math.Sin(2*at)), 1.0/60)
+
+			body := f.Body()
+			if len(body.A) != len(fishWidths)+2 || len(body.B) != len(body.A) {
+				t.Fatalf("scale %v: body outline has %d and %d points, want %d", scale, len(body.A), len(body.B), len(fishWidths)+2)
+			}
+
+			caudal, dorsal := f.CaudalFin(), f.DorsalFin()
+			if want := f.Spine.Len() - 8; len(caudal.A) != want || len(caudal.B) != want {
+				t.Fatalf("scale %v: caudal fin has %d and %d points, want %d", scale, len(caudal.A), len(caudal.B), want)
+			}
+			if len(dorsal.A) != 4 || len(dorsal.B) != 4 {
+				t.Fatalf("scale %v: dorsal fin has %d and %d points, want 4", scale, len(dorsal.A), len(dorsal.B))
+			}
+
+			eyes, radius := f.Eyes()
+			points := append([]kinematics.Vector{f.Head()}, eyes...)
+			for _, r := range []Ribbon{body, caudal, dorsal} {
+				points = append(append(points, r.A...), r.B...)
+			}
+			for _, e := range append(f.PectoralFins(), f.VentralFins()...) {
+				points = append(points, e.Center)
+			}
+			if !finite(points...) || radius != 12*scale {
+				t.Fatalf("scale %v, frame %d: outline is not finite, or eye radius %v", scale, frame, radius)
+			}
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Package creature builds animals out of kinematics chains, after the
+// argonautcode procedural animation demos linked in the README.
+package creature
+
+import (
+	"math"
+
+	"animation/kinematics"
+)
+
+// lizardWidths is the half width of the lizard body at each spine joint, head first
+var lizardWidths = []float64{52, 58, 40, 60, 68, 71, 65, 50, 28, 15, 11, 9, 7, 7}
+
+// Leg is a two bone limb hanging from a spine joint. Its foot stays planted on
+// the ground until the body carries the ideal foot spot too far away, then it
+// steps to the new spot.
+type Leg struct {
+	*kinematics.Limb // Chain is the foot, knee and shoulder, in that order
+
+	Side    float64           // 1 for the right side, -1 for the left
+	Planted kinematics.Vector // Where the foot is heading, or resting once it gets there
+
+	reach    float64 // Angle from the spine heading to the ideal foot spot
+	group    int     // Legs in the same group step together
+	stepping bool
+}
+
+// Lizard is a spine chain with four legs. Diagonal legs step together and
+// the two pairs take turns, which gives the alternating lizard gait.
+type Lizard struct {
+	Spine *kinematics.Chain
+	Legs  []*Leg
+
+	StepDistance float64 // How far the ideal foot spot may drift before the foot steps
+	StepHalfLife float64 // Seconds a stepping foot takes to cover half the way to its new spot
+
+	skeleton *kinematics.Skeleton
+	scale    float64
+}
+
+// NewLizard creates a lizard with its head at origin. A scale of 1 matches
+// the size of the reference demo.
+func NewLizard(origin kinematics.Vector, scale float64) *Lizard {
+	l := &Lizard{
+		Spine:        kinematics.NewChain(origin, len(lizardWidths), int(64*scale), math.Pi/8),
+		StepDistance: 200 * scale,
+		StepHalfLife: 0.05,
+		scale:        scale,
+	}
+	l.skeleton = kinematics.NewSkeleton(l.Spine)
+
+	for i := 0; i < 4; i++ {
+		side := 1.0
+		if i%2 == 1 {
+			side = -1
+		}
+
+		front := i < 2
+		spine, reach, link := 3, math.Pi/4, 52.0
+		if !front {
+			spine, reach, link = 7, math.Pi/3, 36.0
+		}
+
+		// Front elbows point back and hind knees point forward
+		bend := side
+		if !front {
+			bend = -side
+		}
+
+		knee := kinematics.JointSpec{Length: link * scale, Min: 0, Max: 2.5}
+		if bend < 0 {
+			knee.Min, knee.Max = -knee.Max, -knee.Min
+		}
+
+		specs := []kinematics.JointSpec{
+			kinematics.SymmetricJoint(link*scale, math.Pi),
+			kinematics.SymmetricJoint(link*scale, math.Pi),
+			knee,
+		}
+
+		// The spine is the skeleton root and has the shoulder and hip joints, so
+		// attaching to it cannot fail
+		limb, _ := l.skeleton.Attach(l.Spine, spine, math.Pi/2*side, kinematics.NewChainFromSpecs(origin, specs))
+		limb.Distance = l.Width(spine) - 20*scale
+		limb.Reaching = true
+
+		leg := &Leg{
+			Limb:  limb,
+			Side:  side,
+			reach: reach,
+			// Front right and hind left step together, as do front left and hind right
+			group: (i + i/2) % 2,
+		}
+		leg.Planted = l.idealFoot(leg)
+		leg.Target = leg.Planted
+		l.Legs = append(l.Legs, leg)
+	}
+
+	return l
+}
+
+// Width returns the half width of the body at spine joint i
+func (l *Lizard) Width(i int) float64 {
+	return lizardWidths[i] * l.scale
+}
+
+// Shoulder returns where the leg joins the body
+func (l *Lizard) Shoulder(leg *Leg) kinematics.Vector {
+	pos, _ := leg.Root()
+	return pos
+}
+
+// Update moves the head toward target and then plants or steps every foot
+func (l *Lizard) Update(target kinematics.Vector, dt float64) {
+	l.Spine.Resolve(target, dt)
+
+	// A pair may only lift its feet while the other pair is on the ground
+	stepping := [2]bool{}
+	for _, leg := range l.Legs {
+		stepping[leg.group] = stepping[leg.group] || leg.stepping
+	}
+
+	for _, leg := range l.Legs {
+		ideal := l.idealFoot(leg)
+		if !leg.stepping && !stepping[1-leg.group] && ideal.Distance(leg.Planted) > l.StepDistance {
+			leg.Planted = ideal
+			leg.stepping = true
+			stepping[leg.group] = true
+		}
+
+		// Step from the last target rather than the foot, which stops short of spots out of reach
+		foot := leg.Target
+		if leg.stepping {
+			foot = foot.Lerp(leg.Planted, 1-math.Exp2(-dt/l.StepHalfLife))
+			if foot.Distance(leg.Planted) < 1 {
+				foot = leg.Planted
+				leg.stepping = false
+			}
+		} else {
+			foot = leg.Planted
+		}
+
+		leg.Target = foot
+	}
+
+	l.skeleton.ResolveLimbs(dt)
+}
+
+// Head returns the position of the head
+func (l *Lizard) Head() kinematics.Vector {
+	return l.Spine.Head()
+}
+
+// Stepping reports whether the foot of the leg is in the air
+func (leg *Leg) Stepping() bool {
+	return leg.stepping
+}
+
+// idealFoot is where the foot would rest if the lizard stood still
+func (l *Lizard) idealFoot(leg *Leg) kinematics.Vector {
+	return l.bodyPoint(leg.Joint, leg.reach*leg.Side, 80*l.scale)
+}
+
+// bodyPoint returns the point past the body edge at spine joint i, at angle
+// from the spine heading and offset beyond the body width
+func (l *Lizard) bodyPoint(i int, angle, offset float64) kinematics.Vector {
+	heading := l.Spine.Angles()[i] + angle
+	return l.Spine.Joints()[i].Add(kinematics.FromAngle(heading).Multiply(l.Width(i) + offset))
+}
@@ -0,0 +1,43 @@
+package creature
+
+import (
+	"math"
+
+	"animation/kinematics"
+)
+
+// Ribbon is a band between two polylines of the same length. Bodies and fins
+// bend into concave outlines, so they are handed to the drawing layer as
+// ribbons that can be filled one quad at a time.
+type Ribbon struct {
+	A, B []kinematics.Vector
+}
+
+// Outline returns the edge of the ribbon as one closed polygon
+func (r Ribbon) Outline() []kinematics.Vector {
+	points := append([]kinematics.Vector(nil), r.A...)
+	for i := len(r.B) - 1; i >= 0; i-- {
+		points = append(points, r.B[i])
+	}
+
+	return points
+}
+
+// Ellipse is a rotated ellipse, Width and Height are the semi axes
+type Ellipse struct {
+	Center        kinematics.Vector
+	Angle         float64
+	Width, Height float64
+}
+
+// Outline returns segments points around the ellipse
+func (e Ellipse) Outline(segments int) []kinematics.Vector {
+	points := make([]kinematics.Vector, segments)
+	for i := range points {
+		t := 2 * math.Pi * float64(i) / float64(segments)
+		p := kinematics.NewVector(math.Cos(t)*e.Width, math.Sin(t)*e.Height)
+		points[i] = e.Center.Add(p.Rotate(e.Angle))
+	}
+
+	return points
+}
@@ -0,0 +1,102 @@
+// Package binenc holds the little endian reading and writing shared by the
+// binary encodings of packages kinematics and sim.
+package binenc
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// AppendFloats appends each value as 8 little endian bytes
+func AppendFloats(b []byte, values ...float64) []byte {
+	for _, v := range values {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+	}
+
+	return b
+}
+
+// Decoder reads a binary encoding front to back. The first error sticks and
+// every later read returns zero, so callers check Err once at the end.
+type Decoder struct {
+	data []byte
+	err  error
+}
+
+// NewDecoder returns a decoder reading data
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Err returns the first error met, io.ErrUnexpectedEOF when the data ran out
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// Rest returns the data not read yet
+func (d *Decoder) Rest() []byte {
+	return d.data
+}
+
+// Bytes returns the next n bytes
+func (d *Decoder) Bytes(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.data) < n {
+		d.err = io.ErrUnexpectedEOF
+		return nil
+	}
+
+	b := d.data[:n]
+	d.data = d.data[n:]
+
+	return b
+}
+
+// Byte returns the next byte
+func (d *Decoder) Byte() byte {
+	if b := d.Bytes(1); b != nil {
+		return b[0]
+	}
+
+	return 0
+}
+
+// Float returns the next float64
+func (d *Decoder) Float() float64 {
+	if b := d.Bytes(8); b != nil {
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	}
+
+	return 0
+}
+
+// Uvarint returns the next uvarint
+func (d *Decoder) Uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	n, read := binary.Uvarint(d.data)
+	if read <= 0 {
+		d.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	d.data = d.data[read:]
+
+	return n
+}
+
+// Count returns the next uvarint as a count of items of at least size bytes,
+// refusing counts the remaining data could not hold
+func (d *Decoder) Count(size int) int {
+	n := d.Uvarint()
+	if n > uint64(len(d.data)/size) {
+		d.err = io.ErrUnexpectedEOF
+		return 0
+	}
+
+	return int(n)
+}
@@ -0,0 +1,60 @@
+package kinematics
+
+import "math"
+
+const (
+	TwoPi = 2 * math.Pi
+)
+
+// ConstrainAngle constrains the angle to be within a certain range of the anchor.
+// The result is simplified to [0, 2pi).
+func ConstrainAngle(angle, anchor, constraint float64) float64 {
+	if math.Abs(RelativeAngleDiff(angle, anchor)) <= constraint {
+		return SimplifyAngle(angle)
+	}
+
+	if RelativeAngleDiff(angle, anchor) > constraint {
+		return SimplifyAngle(anchor - constraint)
+	}
+
+	return SimplifyAngle(anchor + constraint)
+}
+
+// ConstrainAngleRange constrains the angle to lie between anchor+min and
+// anchor+max, for joints that bend further one way than the other.
+// The result is simplified to [0, 2pi).
+func ConstrainAngleRange(angle, anchor, min, max float64) float64 {
+	diff := -RelativeAngleDiff(angle, anchor)
+
+	if diff < min {
+		return SimplifyAngle(anchor + min)
+	}
+
+	if diff > max {
+		return SimplifyAngle(anchor + max)
+	}
+
+	return SimplifyAngle(angle)
+}
+
+// RelativeAngleDiff computes the radians needed to turn the angle to match the anchor.
+// The result is in (-pi, pi], positive when angle is behind anchor.
+func RelativeAngleDiff(angle, anchor float64) float64 {
+	angle = SimplifyAngle(angle + math.Pi - anchor)
+	anchor = math.Pi
+
+	return anchor - angle
+}
+
+// SimplifyAngle wraps the angle to be in the range [0, 2pi)
+func SimplifyAngle(angle float64) float64 {
+	for angle >= TwoPi {
+		angle -= TwoPi
+	}
+
+	for angle < 0 {
+		angle += TwoPi
+	}
+
+	return angle
+}
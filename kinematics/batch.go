@@ -0,0 +1,193 @@
+package kinematics
+
+import "math"
+
+// Batch resolves many chains of the same build at once, the way FollowLeader
+// resolves one Chain, for crowds too big for a Chain each. The joints of all
+// chains are kept in flat coordinate arrays, chain after chain, and link
+// headings are kept as unit vectors rather than angles, so a Resolve allocates
+// nothing and needs one square root per joint and no trig.
+type Batch struct {
+	count  int // Chains in the batch
+	stride int // Joints per chain
+
+	x, y   []float64 // Joint positions, chain c joint j at c*stride + j
+	hx, hy []float64 // Unit heading of each joint toward the one before it
+
+	length                 []float64 // Link length of each joint, shared by all chains
+	minX, minY, maxX, maxY []float64 // Bend range of each joint as unit turns
+
+	halfLife float64
+}
+
+// NewBatch creates count chains built from specs, all laid out straight down
+// from origin. Radius and the spring settings of the specs are not used.
+// With no specs every chain is a lone head with a zero spec, like
+// NewChainFromSpecs.
+func NewBatch(count int, origin Vector, specs []JointSpec) *Batch {
+	if len(specs) == 0 {
+		specs = []JointSpec{{}}
+	}
+
+	n := len(specs)
+	b := &Batch{
+		count:    count,
+		stride:   n,
+		x:        make([]float64, count*n),
+		y:        make([]float64, count*n),
+		hx:       make([]float64, count*n),
+		hy:       make([]float64, count*n),
+		length:   make([]float64, n),
+		minX:     make([]float64, n),
+		minY:     make([]float64, n),
+		maxX:     make([]float64, n),
+		maxY:     make([]float64, n),
+		halfLife: DefaultHalfLife,
+	}
+
+	for j, spec := range specs {
+		b.length[j] = spec.Length
+		b.minY[j], b.minX[j] = math.Sincos(spec.Min)
+		b.maxY[j], b.maxX[j] = math.Sincos(spec.Max)
+	}
+
+	for c := 0; c < count; c++ {
+		b.Place(c, origin)
+	}
+
+	return b
+}
+
+// Len returns the number of chains
+func (b *Batch) Len() int {
+	return b.count
+}
+
+// Joints returns the number of joints in each chain
+func (b *Batch) Joints() int {
+	return b.stride
+}
+
+// SetHalfLife sets how many seconds the heads take to close half the distance
+// to their targets. Zero or less makes the heads snap to their targets.
+func (b *Batch) SetHalfLife(seconds float64) {
+	b.halfLife = math.Max(seconds, 0)
+}
+
+// Place lays chain c out straight down from origin
+func (b *Batch) Place(c int, origin Vector) {
+	base := c * b.stride
+	px, py := origin.X, origin.Y
+	for j := 0; j < b.stride; j++ {
+		if j > 0 {
+			py += b.length[j]
+		}
+
+		b.x[base+j], b.y[base+j] = px, py
+		b.hx[base+j], b.hy[base+j] = 0, -1
+	}
+}
+
+// Joint returns the position of joint j of chain c
+func (b *Batch) Joint(c, j int) Vector {
+	i := c*b.stride + j
+	return Vector{X: b.x[i], Y: b.y[i]}
+}
+
+// Head returns the position of the head of chain c
+func (b *Batch) Head(c int) Vector {
+	return b.Joint(c, 0)
+}
+
+// Heading returns the heading of joint j of chain c toward the joint before
+// it, as an angle like Chain.Angles
+func (b *Batch) Heading(c, j int) float64 {
+	i := c*b.stride + j
+	return math.Atan2(b.hy[i], b.hx[i])
+}
+
+// AppendJoints appends the joint positions of chain c, head first, to dst
+func (b *Batch) AppendJoints(dst []Vector, c int) []Vector {
+	base := c * b.stride
+	for j := 0; j < b.stride; j++ {
+		dst = append(dst, Vector{X: b.x[base+j], Y: b.y[base+j]})
+	}
+
+	return dst
+}
+
+// Resolve moves the head of every chain toward its target, dt seconds after
+// the last call, and drags the other joints after it. targets holds one
+// target per chain.
+func (b *Batch) Resolve(targets []Vector, dt float64) {
+	b.ResolveRange(targets, dt, 0, b.count)
+}
+
+// ResolveRange resolves chains from up to but not including to. Separate
+// ranges share no memory, so they can be resolved on separate goroutines.
+func (b *Batch) ResolveRange(targets []Vector, dt float64, from, to int) {
+	t := 1.0
+	if b.halfLife > 0 {
+		t = 1 - math.Exp2(-dt/b.halfLife)
+	}
+
+	for c := from; c < to; c++ {
+		b.resolve(c, targets[c], t)
+	}
+}
+
+// resolve moves the head of chain c the fraction t of the way to target and
+// lets the rest follow
+func (b *Batch) resolve(c int, target Vector, t float64) {
+	base := c * b.stride
+	x := b.x[base : base+b.stride]
+	y := b.y[base : base+b.stride]
+	hx := b.hx[base : base+b.stride]
+	hy := b.hy[base : base+b.stride]
+
+	fromX, fromY := x[0], y[0]
+	if t < 1 {
+		x[0] += (target.X - x[0]) * t
+		y[0] += (target.Y - y[0]) * t
+	} else {
+		x[0], y[0] = target.X, target.Y
+	}
+
+	// The head faces its target, or once it arrives the way it moved, like Chain
+	if dx, dy := target.X-x[0], target.Y-y[0]; dx != 0 || dy != 0 {
+		inv := 1 / math.Sqrt(dx*dx+dy*dy)
+		hx[0], hy[0] = dx*inv, dy*inv
+	} else if dx, dy := x[0]-fromX, y[0]-fromY; dx != 0 || dy != 0 {
+		inv := 1 / math.Sqrt(dx*dx+dy*dy)
+		hx[0], hy[0] = dx*inv, dy*inv
+	}
+
+	for j := 1; j < len(x); j++ {
+		ux, uy := x[j-1]-x[j], y[j-1]-y[j]
+		if d := ux*ux + uy*uy; d > 0 {
+			inv := 1 / math.Sqrt(d)
+			ux, uy = ux*inv, uy*inv
+		} else {
+			ux, uy = hx[j], hy[j]
+		}
+
+		// Turn from the previous heading, as a unit vector: cos and sin
+		vx, vy := hx[j-1], hy[j-1]
+		tc, ts := ux*vx+uy*vy, vx*uy-vy*ux
+
+		// Outside the range on either side snaps to that bound, like ConstrainAngleRange
+		if ts >= 0 {
+			if tc*b.maxY[j]-ts*b.maxX[j] < 0 {
+				tc, ts = b.maxX[j], b.maxY[j]
+				ux, uy = vx*tc-vy*ts, vx*ts+vy*tc
+			}
+		} else if b.minX[j]*ts-b.minY[j]*tc < 0 {
+			tc, ts = b.minX[j], b.minY[j]
+			ux, uy = vx*tc-vy*ts, vx*ts+vy*tc
+		}
+
+		hx[j], hy[j] = ux, uy
+		x[j] = x[j-1] - ux*b.length[j]
+		y[j] = y[j-1] - uy*b.length[j]
+	}
+}
@@ -0,0 +1,99 @@
+package kinematics
+
+import (
+	"math"
+	"testing"
+)
+
+// wander returns where chain c chases at step i, a different loop for every chain
+func wander(c, i int) Vector {
+	t := float64(i) / 20
+	return NewVector(400+200*math.Cos(t+float64(c)), 300+150*math.Sin(2*t+float64(c)/3))
+}
+
+func TestBatchMatchesChain(t *testing.T) {
+	const count, steps = 8, 300
+	origin := NewVector(400, 300)
+
+	chains := make([]*Chain, count)
+	for c := range chains {
+		chains[c] = NewChain(origin, 12, 16, math.Pi/6)
+	}
+
+	specs := make([]JointSpec, chains[0].Len())
+	for j := range specs {
+		specs[j] = chains[0].Spec(j)
+	}
+	b := NewBatch(count, origin, specs)
+
+	targets := make([]Vector, count)
+	for i := 1; i <= steps; i++ {
+		for c := range targets {
+			targets[c] = wander(c, i)
+			chains[c].Resolve(targets[c], 1.0/60)
+		}
+		b.Resolve(targets, 1.0/60)
+
+		for c, chain := range chains {
+			for j, want := range chain.Joints() {
+				if got := b.Joint(c, j); got.Distance(want) > 1e-6 {
+					t.Fatalf("step %d: chain %d joint %d at %v, want %v", i, c, j, got, want)
+				}
+				if got, want := b.Heading(c, j), chain.Angles()[j]; math.Abs(angleDelta(want, got)) > 1e-6 {
+					t.Fatalf("step %d: chain %d joint %d heads %v, want %v", i, c, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+// The two benchmarks resolve the same crowd, one Chain each against one Batch
+
+const benchChains, benchJoints = 1000, 24
+
+func BenchmarkChainResolve(b *testing.B) {
+	chains := make([]*Chain, benchChains)
+	for c := range chains {
+		chains[c] = NewChain(NewVector(400, 300), benchJoints, 16, math.Pi/6)
+	}
+
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		for c, chain := range chains {
+			chain.Resolve(wander(c, i), 1.0/60)
+		}
+	}
+}
+
+func BenchmarkBatchResolve(b *testing.B) {
+	specs := make([]JointSpec, benchJoints)
+	for j := range specs {
+		specs[j] = SymmetricJoint(16, math.Pi/6)
+	}
+	batch := NewBatch(benchChains, NewVector(400, 300), specs)
+	targets := make([]Vector, benchChains)
+
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		for c := range targets {
+			targets[c] = wander(c, i)
+		}
+		batch.Resolve(targets, 1.0/60)
+	}
+}
+
+func TestBatchWithoutSpecs(t *testing.T) {
+	b := NewBatch(3, NewVector(0, 0), nil)
+	if b.Joints() != 1 {
+		t.Fatalf("got %d joints from no specs, want 1", b.Joints())
+	}
+
+	b.SetHalfLife(0)
+	targets := []Vector{NewVector(10, 0), NewVector(0, 20), NewVector(-30, 5)}
+	b.Resolve(targets, 1.0/60)
+	for c, want := range targets {
+		if got := b.Head(c); got != want {
+			t.Errorf("chain %d head at %v, want %v", c, got, want)
+		}
+	}
+}
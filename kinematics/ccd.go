@@ -0,0 +1,62 @@
+package kinematics
+
+// CCD reaches the head toward the target with cyclic coordinate descent. The
+// tail stays pinned at Anchor and each joint in turn, from the head back to
+// the tail, rotates the part of the chain in front of it so the head points
+// at the target. Turns are limited by the joint bend ranges. Zero Iterations
+// and Tolerance use DefaultIterations and DefaultTolerance.
+type CCD struct {
+	Anchor     Vector
+	Iterations int
+	Tolerance  float64
+}
+
+// Solve implements Solver
+func (s CCD) Solve(c *Chain, target Vector, _ float64) Result {
+	iterations, tolerance := withDefaults(s.Iterations, s.Tolerance)
+
+	last := len(c.joints) - 1
+	if last == 0 {
+		c.joints[0] = s.Anchor
+		return Result{Error: s.Anchor.Distance(target)}
+	}
+
+	c.measureAngles()
+	c.layoutFrom(s.Anchor)
+
+	iter := 0
+	for iter < iterations {
+		iter++
+
+		for pivot := 1; pivot <= last; pivot++ {
+			toHead := c.joints[0].Subtract(c.joints[pivot])
+			toTarget := target.Subtract(c.joints[pivot])
+			if toHead.MagnitudeSquared() == 0 || toTarget.MagnitudeSquared() == 0 {
+				continue
+			}
+
+			delta := c.clampTurn(pivot, angleDelta(toHead.Angle(), toTarget.Angle()))
+			if delta == 0 {
+				continue
+			}
+
+			// Swing every joint in front of the pivot around it
+			for i := 0; i < pivot; i++ {
+				c.joints[i] = c.joints[pivot].Add(c.joints[i].Subtract(c.joints[pivot]).Rotate(delta))
+			}
+
+			for i := 1; i <= pivot; i++ {
+				c.angles[i] = SimplifyAngle(c.angles[i] + delta)
+			}
+		}
+
+		if c.joints[0].DistanceSquared(target) <= tolerance*tolerance {
+			break
+		}
+	}
+
+	// Rebuild from the headings so rotation round-off never stretches a link
+	c.layoutFrom(s.Anchor)
+
+	return Result{Iterations: iter, Error: c.joints[0].Distance(target)}
+}
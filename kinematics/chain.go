@@ -0,0 +1,391 @@
+package kinematics
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	// DefaultHalfLife is the time, in seconds, the head takes to cover half the
+	// distance to its target. It matches the old 10% per frame at 60 FPS.
+	DefaultHalfLife = 0.11
+)
+
+// JointSpec describes the link from a joint to the one before it and how far
+// that link may bend relative to the previous link. Min and Max are in
+// radians, with Min <= 0 <= Max; a loose joint has a wide range, a rigid one a
+// narrow range.
+type JointSpec struct {
+	Length float64 `json:"length"` // Space to the previous joint
+	Min    float64 `json:"min"`    // Most the link may turn toward smaller headings
+	Max    float64 `json:"max"`    // Most the link may turn toward larger headings
+	Radius float64 `json:"radius"` // Body radius around the joint, kept clear of colliders
+
+	// With Stiffness set the joint is an angular spring: it bends back toward
+	// Rest instead of hanging at whatever angle it was dragged to, and Min and
+	// Max are only a last resort limit
+	Rest      float64 `json:"rest,omitempty"`      // Bend the spring settles at, in radians
+	Stiffness float64 `json:"stiffness,omitempty"` // Spring strength per second squared, 0 = no spring
+	Damping   float64 `json:"damping,omitempty"`   // How fast the spring stops swinging, per second
+}
+
+// SymmetricJoint returns a spec of the given length that bends up to
+// constraint either way
+func SymmetricJoint(length, constraint float64) JointSpec {
+	return JointSpec{Length: length, Min: -constraint, Max: constraint}
+}
+
+// Chain is a sequence of joints where each joint is held at its spec length
+// from the one before it, and bends within its spec range. The first joint is
+// the head.
+type Chain struct {
+	joints    []Vector
+	angles    []float64      // Heading of each joint toward the one before it
+	specs     []JointSpec    // Length, bend range and radius of each joint
+	solver    Solver         // Algorithm used by Resolve
+	halfLife  float64        // Seconds for the head to close half the gap to its target, 0 = snap
+	colliders []Collider     // Shapes the joints are pushed out of after every Resolve
+	self      *selfCollision // Optional pass keeping the chain from crossing itself
+	growth    []growth       // Links easing toward their spec length, zero when fully grown
+	spin      []float64      // How fast each spring joint is bending, in radians per second
+	path      []Vector       // Head positions recorded by FollowPath, oldest first
+	motion    []motion       // How each joint moved over the last timed Resolve
+}
+
+// NewChain creates a chain of jointCount joints laid out straight down from
+// origin, all linkSize apart and bending up to angleConstraint either way.
+// A chain always has a head, so a jointCount below 1 gives one joint.
+func NewChain(origin Vector, jointCount int, linkSize int, angleConstraint float64) *Chain {
+	specs := make([]JointSpec, max(jointCount, 1))
+	for i := range specs {
+		specs[i] = SymmetricJoint(float64(linkSize), angleConstraint)
+	}
+
+	return NewChainFromSpecs(origin, specs)
+}
+
+// NewChainFromSpecs creates a chain with one joint per spec laid out straight
+// down from origin. The head has no previous joint, so apart from its Radius
+// specs[0] is only used as the template when a joint is added to a one joint
+// chain. With no specs the chain is a lone head with a zero spec.
+func NewChainFromSpecs(origin Vector, specs []JointSpec) *Chain {
+	if len(specs) == 0 {
+		specs = []JointSpec{{}}
+	}
+
+	c := &Chain{
+		specs:    append([]JointSpec(nil), specs...),
+		solver:   FollowLeader{},
+		halfLife: DefaultHalfLife,
+	}
+
+	c.joints = append(c.joints, origin)
+	c.angles = append(c.angles, 0)
+
+	for i := 1; i < len(specs); i++ {
+		c.joints = append(c.joints, c.joints[i-1].Add(NewVector(0, specs[i].Length)))
+		c.angles = append(c.angles, 0)
+	}
+
+	c.growth = make([]growth, len(c.joints))
+	c.spin = make([]float64, len(c.joints))
+	c.resetMotion()
+
+	return c
+}
+
+// Joints returns the joint positions, head first. The slice must not be modified.
+func (c *Chain) Joints() []Vector {
+	return c.joints
+}
+
+// Angles returns the heading of each joint in radians. The slice must not be modified.
+func (c *Chain) Angles() []float64 {
+	return c.angles
+}
+
+// Len returns the number of joints
+func (c *Chain) Len() int {
+	return len(c.joints)
+}
+
+// Head returns the position of the first joint
+func (c *Chain) Head() Vector {
+	return c.joints[0]
+}
+
+// Spec returns the length and bend range of joint i. A growing link reports
+// the length it is growing toward.
+func (c *Chain) Spec(i int) JointSpec {
+	spec := c.specs[i]
+	if c.Growing(i) {
+		spec.Length = c.growth[i].length
+	}
+
+	return spec
+}
+
+// SetSpec changes the length and bend range of joint i. A growing link keeps
+// growing toward the new length. The joints move to match on the next Resolve.
+func (c *Chain) SetSpec(i int, spec JointSpec) {
+	if c.Growing(i) {
+		c.growth[i].length = spec.Length
+		spec.Length = min(spec.Length, c.specs[i].Length)
+	}
+
+	c.specs[i] = spec
+}
+
+// Length returns the rest length of the whole chain, head to tail
+func (c *Chain) Length() float64 {
+	length := 0.0
+	for i := 1; i < len(c.specs); i++ {
+		length += c.specs[i].Length
+	}
+
+	return length
+}
+
+// SetSolver changes the algorithm used by Resolve and forgets any recorded
+// path. A nil solver restores FollowLeader.
+func (c *Chain) SetSolver(s Solver) {
+	if s == nil {
+		s = FollowLeader{}
+	}
+
+	c.solver = s
+	c.ClearPath()
+}
+
+// Solver returns the algorithm used by Resolve
+func (c *Chain) Solver() Solver {
+	return c.solver
+}
+
+// SetHalfLife sets how many seconds the head takes to close half the distance
+// to its target. Zero or less makes the head snap to the target.
+func (c *Chain) SetHalfLife(seconds float64) {
+	c.halfLife = math.Max(seconds, 0)
+}
+
+// HalfLife returns the head smoothing half-life in seconds
+func (c *Chain) HalfLife() float64 {
+	return c.halfLife
+}
+
+// Resolve moves the chain toward pos with the configured solver, dt seconds
+// after the last call. A dt of 0 re-applies the constraints without moving
+// the head, so extra calls in the same frame do not speed the chain up.
+// Joints are then pushed out of any colliders, and the motion of every joint
+// is measured.
+func (c *Chain) Resolve(pos Vector, dt float64) Result {
+	c.grow(dt)
+
+	result := c.solver.Solve(c, pos, dt)
+	c.collide()
+	c.track(dt)
+
+	return result
+}
+
+// smoothing returns the fraction of the way to its target the head moves in dt
+func (c *Chain) smoothing(dt float64) float64 {
+	if c.halfLife <= 0 {
+		return 1
+	}
+
+	return 1 - math.Exp2(-dt/c.halfLife)
+}
+
+// followLeader moves the head toward pos and drags the rest of the chain after it
+func (c *Chain) followLeader(pos Vector, dt float64) {
+	c.moveHead(pos, dt)
+	c.follow(dt)
+}
+
+// moveHead moves the head toward pos, smoothed by the half-life, and points
+// it at pos. A head that lands on pos faces the way it moved, or keeps its
+// heading if it did not move.
+func (c *Chain) moveHead(pos Vector, dt float64) {
+	from := c.joints[0]
+
+	// Exponential smoothing by half-life covers the same ground at any frame rate.
+	// A snapping head lands exactly on pos, the lerp can stop a rounding error
+	// short and the heading would point along that error.
+	if t := c.smoothing(dt); t < 1 {
+		c.joints[0] = from.Lerp(pos, t)
+	} else {
+		c.joints[0] = pos
+	}
+
+	if ahead := pos.Subtract(c.joints[0]); ahead.MagnitudeSquared() > 0 {
+		c.angles[0] = ahead.Angle()
+	} else if moved := c.joints[0].Subtract(from); moved.MagnitudeSquared() > 0 {
+		c.angles[0] = moved.Angle()
+	}
+}
+
+// Attach puts the head at pos facing heading and drags the rest of the chain
+// after it, for chains that hang off a joint of another chain. dt is the time
+// since the last call, which swings any spring joints.
+func (c *Chain) Attach(pos Vector, heading, dt float64) {
+	c.joints[0] = pos
+	c.angles[0] = SimplifyAngle(heading)
+
+	c.follow(dt)
+	c.track(dt)
+}
+
+// Translate moves every joint and the FollowPath trail by offset, keeping the
+// pose. Use it to push a chain whose solver does not steer the head to a
+// target, such as Serpenoid.
+func (c *Chain) Translate(offset Vector) {
+	for i := range c.joints {
+		c.joints[i] = c.joints[i].Add(offset)
+	}
+
+	for i := range c.path {
+		c.path[i] = c.path[i].Add(offset)
+	}
+}
+
+// follow drags every joint after the one before it, springs the joints that
+// have a Stiffness dt seconds toward rest, and keeps them within their spec
+func (c *Chain) follow(dt float64) {
+	for i := 1; i < len(c.joints); i++ {
+		curAngle := c.heading(i)
+		if c.specs[i].Stiffness > 0 && dt > 0 {
+			curAngle = c.spring(i, curAngle, dt)
+		}
+
+		c.angles[i] = c.bend(i, curAngle)
+		if c.angles[i] != SimplifyAngle(curAngle) {
+			// Stopped by the hard limit
+			c.spin[i] = 0
+		}
+
+		c.joints[i] = c.joints[i-1].Subtract(FromAngle(c.angles[i]).SetMag(c.specs[i].Length))
+	}
+}
+
+// spring swings the heading of link i dt seconds toward its rest bend around
+// link i-1. The step is implicit, so stiff springs stay stable at any frame rate.
+func (c *Chain) spring(i int, heading, dt float64) float64 {
+	spec := c.specs[i]
+	bend := angleDelta(c.angles[i-1], heading)
+
+	c.spin[i] = (c.spin[i] - dt*spec.Stiffness*(bend-spec.Rest)) / (1 + dt*spec.Damping + dt*dt*spec.Stiffness)
+
+	return c.angles[i-1] + bend + c.spin[i]*dt
+}
+
+// SetSprings turns every joint but the head into an angular spring with the
+// given stiffness and damping, resting at its current Rest bend. A stiffness
+// of 0 turns the springs off.
+func (c *Chain) SetSprings(stiffness, damping float64) {
+	for i := 1; i < len(c.specs); i++ {
+		c.specs[i].Stiffness = stiffness
+		c.specs[i].Damping = damping
+	}
+
+	clear(c.spin)
+}
+
+// bend limits the heading of link i to its spec range around link i-1
+func (c *Chain) bend(i int, heading float64) float64 {
+	return ConstrainAngleRange(heading, c.angles[i-1], c.specs[i].Min, c.specs[i].Max)
+}
+
+// bendBack limits the heading of link i so that link i+1 stays within its
+// spec range around it, for solvers that work from the tail forward
+func (c *Chain) bendBack(i int, heading float64) float64 {
+	return ConstrainAngleRange(heading, c.angles[i+1], -c.specs[i+1].Max, -c.specs[i+1].Min)
+}
+
+// DeleteJoint removes the tail joint, keeping at least 3 joints. It reports
+// whether a joint was removed.
+func (c *Chain) DeleteJoint() bool {
+	if len(c.joints) <= 3 {
+		return false
+	}
+
+	return c.RemoveJoint(len(c.joints) - 1)
+}
+
+// AddJoint appends a joint to the tail, continuing the direction of the last
+// link. The new joint copies the spec of the current tail.
+func (c *Chain) AddJoint() {
+	c.InsertJoint(len(c.joints))
+}
+
+// InsertJoint adds a joint so that it ends up at index, from 0 (a new head)
+// to Len (a new tail). A joint between two others starts halfway between them
+// and copies the spec of the joint it is inserted in front of. A new head or
+// tail extends the chain along its end link.
+func (c *Chain) InsertJoint(index int) {
+	index = max(0, min(index, len(c.joints)))
+	last := len(c.joints) - 1
+
+	var joint Vector
+	var spec JointSpec
+	angle := c.angles[min(index, last)]
+
+	switch {
+	case index == len(c.joints):
+		// Add new joint in the direction from second last to last joint
+		spec = c.Spec(last)
+		direction := NewVector(0, 1)
+		if last > 0 {
+			direction = c.joints[last].Subtract(c.joints[last-1]).Normalize()
+		}
+		joint = c.joints[last].Add(direction.SetMag(spec.Length))
+
+	case index == 0:
+		// The new head takes the head spec, the old head takes the first link spec
+		spec = c.specs[0]
+		if last > 0 {
+			c.specs[0] = c.Spec(1)
+		}
+		joint = c.joints[0].Add(FromAngle(c.angles[0]).SetMag(c.specs[0].Length))
+
+	default:
+		spec = c.Spec(index)
+		joint = c.joints[index-1].Lerp(c.joints[index], 0.5)
+	}
+
+	c.joints = slices.Insert(c.joints, index, joint)
+	c.angles = slices.Insert(c.angles, index, angle)
+	c.specs = slices.Insert(c.specs, index, spec)
+	c.growth = slices.Insert(c.growth, index, growth{})
+	c.spin = slices.Insert(c.spin, index, 0)
+	c.motion = slices.Insert(c.motion, index, motion{})
+
+	// Resolve the chain to ensure proper positioning
+	c.Resolve(c.joints[0], 0)
+	c.rest(index)
+}
+
+// RemoveJoint takes out the joint at index and closes the gap, the joints on
+// either side are joined by the link of the joint that followed it. A chain
+// always keeps at least one joint. It reports whether a joint was removed.
+func (c *Chain) RemoveJoint(index int) bool {
+	if index < 0 || index >= len(c.joints) || len(c.joints) == 1 {
+		return false
+	}
+
+	if index == 0 {
+		// The second joint becomes the head, keep the head spec as the template
+		c.specs[1], c.growth[1] = c.specs[0], growth{}
+	}
+
+	c.joints = slices.Delete(c.joints, index, index+1)
+	c.angles = slices.Delete(c.angles, index, index+1)
+	c.specs = slices.Delete(c.specs, index, index+1)
+	c.growth = slices.Delete(c.growth, index, index+1)
+	c.spin = slices.Delete(c.spin, index, index+1)
+	c.motion = slices.Delete(c.motion, index, index+1)
+
+	c.Resolve(c.joints[0], 0)
+
+	return true
+}
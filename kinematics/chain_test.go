@@ -0,0 +1,110 @@
+package kinematics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSnapTrailsBehind(t *testing.T) {
+	for name, solver := range map[string]Solver{"FollowLeader": FollowLeader{}, "FollowPath": FollowPath{}} {
+		t.Run(name, func(t *testing.T) {
+			c := NewChain(NewVector(0, 0), 6, 20, math.Pi/8)
+			c.SetSolver(solver)
+			c.SetHalfLife(0)
+
+			// Straight down, far enough for the whole body to follow
+			for i := 1; i <= 100; i++ {
+				c.Resolve(NewVector(0, float64(i)*5), 1.0/60)
+			}
+
+			head := c.Head()
+			for i, p := range c.Joints() {
+				want := head.Subtract(NewVector(0, float64(i)*20))
+				if p.Distance(want) > 1e-3 {
+					t.Errorf("joint %d is %g off straight behind the head", i, p.Distance(want))
+				}
+			}
+		})
+	}
+}
+
+func TestSnapFacesTheWayItMoved(t *testing.T) {
+	c := NewChain(NewVector(0, 0), 4, 20, math.Pi/8)
+	c.SetHalfLife(0)
+
+	// Lerping all the way to these targets leaves a rounding error behind
+	for i := 1; i <= 20; i++ {
+		at := float64(i)
+		target := NewVector(at*4, 30*math.Sin(at/5))
+		from := c.Head()
+
+		c.Resolve(target, 1.0/60)
+		if c.Head() != target {
+			t.Fatalf("step %d: head at %v, want it on %v", i, c.Head(), target)
+		}
+		if want := target.Subtract(from).Angle(); math.Abs(angleDelta(want, c.Angles()[0])) > 1e-9 {
+			t.Fatalf("step %d: head faces %v, want %v", i, c.Angles()[0], want)
+		}
+	}
+}
+
+func TestEmptyChainGrows(t *testing.T) {
+	c := NewChain(NewVector(0, 0), 0, 20, math.Pi/8)
+	c.SetColliders(Circle{Center: NewVector(100, 100), Radius: 10})
+	c.Resolve(NewVector(0, 0), 1.0/60)
+
+	c.AddJoint()
+	c.AddJoint()
+	if c.Len() != 3 {
+		t.Fatalf("got %d joints, want 3", c.Len())
+	}
+
+	checkLinks(t, c)
+	if got := c.Length(); got != 40 {
+		t.Errorf("chain is %v long, want 40", got)
+	}
+
+	if c := NewChainFromSpecs(NewVector(0, 0), nil); c.Len() != 1 {
+		t.Errorf("got %d joints from no specs, want 1", c.Len())
+	}
+}
+
+func TestSpringsIgnoreHeading(t *testing.T) {
+	// bends returns the bend at every joint along a swerving run of a spring
+	// rig heading the given way
+	bends := func(heading float64) [][]float64 {
+		forward := FromAngle(heading)
+		c := NewChainFromPoints([]Vector{{}, forward.Multiply(-1000)}, []JointSpec{
+			{}, SymmetricJoint(20, math.Pi/2), SymmetricJoint(20, math.Pi/2), SymmetricJoint(20, math.Pi/2),
+		})
+		c.SetHalfLife(0)
+		c.SetSprings(200, 5)
+
+		var out [][]float64
+		for i := 1; i <= 60; i++ {
+			at := float64(i)
+			swerve := NewVector(at*4, 30*math.Sin(at/5))
+			c.Resolve(swerve.Rotate(heading), 1.0/60)
+
+			angles := c.Angles()
+			step := make([]float64, len(angles)-1)
+			for j := range step {
+				step[j] = angleDelta(angles[j], angles[j+1])
+			}
+			out = append(out, step)
+		}
+
+		return out
+	}
+
+	want := bends(0)
+	for _, heading := range []float64{math.Pi / 2, 2.5} {
+		for i, step := range bends(heading) {
+			for j, bend := range step {
+				if math.Abs(bend-want[i][j]) > 1e-6 {
+					t.Fatalf("heading %v, step %d: joint %d bends %v, want %v", heading, i, j+1, bend, want[i][j])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package kinematics
+
+import "math"
+
+// Collider is a shape that joints are pushed out of
+type Collider interface {
+	// Push moves p so a joint of the given radius at p no longer overlaps the
+	// shape. It reports whether p had to move.
+	Push(p Vector, radius float64) (Vector, bool)
+}
+
+// Circle is a solid round obstacle
+type Circle struct {
+	Center Vector
+	Radius float64
+}
+
+// Push implements Collider
+func (c Circle) Push(p Vector, radius float64) (Vector, bool) {
+	diff := p.Subtract(c.Center)
+	reach := c.Radius + radius
+	if diff.MagnitudeSquared() >= reach*reach {
+		return p, false
+	}
+
+	if diff.MagnitudeSquared() == 0 {
+		diff = Vector{X: 1}
+	}
+
+	return c.Center.Add(diff.SetMag(reach)), true
+}
+
+// Segment is a thin wall between A and B
+type Segment struct {
+	A, B Vector
+}
+
+// Push implements Collider
+func (s Segment) Push(p Vector, radius float64) (Vector, bool) {
+	q := s.Closest(p)
+	diff := p.Subtract(q)
+	if diff.MagnitudeSquared() >= radius*radius {
+		return p, false
+	}
+
+	if diff.MagnitudeSquared() == 0 {
+		// Sitting on the wall, push out along its normal
+		wall := s.B.Subtract(s.A)
+		diff = Vector{X: -wall.Y, Y: wall.X}
+		if diff.MagnitudeSquared() == 0 {
+			diff = Vector{X: 1}
+		}
+	}
+
+	return q.Add(diff.SetMag(radius)), true
+}
+
+// Closest returns the point on the segment nearest to p
+func (s Segment) Closest(p Vector) Vector {
+	wall := s.B.Subtract(s.A)
+	length := wall.MagnitudeSquared()
+	if length == 0 {
+		return s.A
+	}
+
+	t := math.Max(0, math.Min(1, p.Subtract(s.A).Dot(wall)/length))
+	return s.A.Lerp(s.B, t)
+}
+
+// Box is a solid axis aligned obstacle from Min to Max
+type Box struct {
+	Min, Max Vector
+}
+
+// Push implements Collider
+func (b Box) Push(p Vector, radius float64) (Vector, bool) {
+	left := p.X - (b.Min.X - radius)
+	right := (b.Max.X + radius) - p.X
+	top := p.Y - (b.Min.Y - radius)
+	bottom := (b.Max.Y + radius) - p.Y
+	if left <= 0 || right <= 0 || top <= 0 || bottom <= 0 {
+		return p, false
+	}
+
+	// Leave through the nearest side
+	switch math.Min(math.Min(left, right), math.Min(top, bottom)) {
+	case left:
+		p.X -= left
+	case right:
+		p.X += right
+	case top:
+		p.Y -= top
+	default:
+		p.Y += bottom
+	}
+
+	return p, true
+}
+
+// Bounds keeps joints inside the area from Min to Max, like the world edges
+type Bounds struct {
+	Min, Max Vector
+}
+
+// Push implements Collider
+func (b Bounds) Push(p Vector, radius float64) (Vector, bool) {
+	q := Vector{
+		X: math.Max(b.Min.X+radius, math.Min(b.Max.X-radius, p.X)),
+		Y: math.Max(b.Min.Y+radius, math.Min(b.Max.Y-radius, p.Y)),
+	}
+
+	return q, q != p
+}
+
+// SetColliders replaces the shapes the joints are kept out of. Every Resolve
+// then finishes with a collision pass.
+func (c *Chain) SetColliders(colliders ...Collider) {
+	c.colliders = colliders
+}
+
+// AddCollider adds a shape the joints are kept out of
+func (c *Chain) AddCollider(collider Collider) {
+	c.colliders = append(c.colliders, collider)
+}
+
+// Colliders returns the shapes the joints are kept out of. The slice must not be modified.
+func (c *Chain) Colliders() []Collider {
+	return c.colliders
+}
+
+// collide pushes every joint out of the colliders and, with self collision
+// on, away from the rest of the body. It then drags the chain back to its link
+// lengths from the head, sliding joints along what they hit.
+func (c *Chain) collide() {
+	if len(c.colliders) == 0 && c.self == nil {
+		return
+	}
+
+	for iter := 0; iter < DefaultIterations; iter++ {
+		moved := c.self != nil && c.separate()
+		for i := range c.joints {
+			for _, collider := range c.colliders {
+				var hit bool
+				c.joints[i], hit = collider.Push(c.joints[i], c.specs[i].Radius)
+				moved = moved || hit
+			}
+		}
+
+		if !moved {
+			break
+		}
+
+		for i := 1; i < len(c.joints); i++ {
+			length := c.specs[i].Length
+			c.joints[i] = ConstrainDistance(c.joints[i-1], c.joints[i], length, length)
+		}
+	}
+
+	c.measureAngles()
+}
@@ -0,0 +1,88 @@
+package kinematics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestColliderPush(t *testing.T) {
+	tests := []struct {
+		name     string
+		collider Collider
+		p        Vector
+		radius   float64
+		want     Vector
+		hit      bool
+	}{
+		{"circle inside", Circle{Radius: 10}, NewVector(5, 0), 2, NewVector(12, 0), true},
+		{"circle outside", Circle{Radius: 10}, NewVector(20, 0), 2, NewVector(20, 0), false},
+		{"circle boundary", Circle{Radius: 10}, NewVector(0, 12), 2, NewVector(0, 12), false},
+		{"circle center", Circle{Radius: 10}, NewVector(0, 0), 2, NewVector(12, 0), true},
+		{"circle zero radius", Circle{}, NewVector(0, 1), 2, NewVector(0, 2), true},
+		{"circle zero radius point", Circle{}, NewVector(0, 0), 0, NewVector(0, 0), false},
+
+		{"segment inside", Segment{B: NewVector(10, 0)}, NewVector(5, 1), 2, NewVector(5, 2), true},
+		{"segment outside", Segment{B: NewVector(10, 0)}, NewVector(5, -3), 2, NewVector(5, -3), false},
+		{"segment boundary", Segment{B: NewVector(10, 0)}, NewVector(5, 2), 2, NewVector(5, 2), false},
+		{"segment on the wall", Segment{B: NewVector(10, 0)}, NewVector(5, 0), 2, NewVector(5, 2), true},
+		{"segment past the end", Segment{B: NewVector(10, 0)}, NewVector(11, 0), 2, NewVector(12, 0), true},
+		{"segment zero length", Segment{A: NewVector(3, 3), B: NewVector(3, 3)}, NewVector(3, 4), 2, NewVector(3, 5), true},
+		{"segment zero length on it", Segment{A: NewVector(3, 3), B: NewVector(3, 3)}, NewVector(3, 3), 2, NewVector(5, 3), true},
+
+		{"box inside", Box{Max: NewVector(10, 10)}, NewVector(1, 5), 2, NewVector(-2, 5), true},
+		{"box inside near the bottom", Box{Max: NewVector(10, 10)}, NewVector(5, 11), 2, NewVector(5, 12), true},
+		{"box outside", Box{Max: NewVector(10, 10)}, NewVector(-3, 5), 2, NewVector(-3, 5), false},
+		{"box boundary", Box{Max: NewVector(10, 10)}, NewVector(-2, 5), 2, NewVector(-2, 5), false},
+		{"box zero size", Box{Min: NewVector(5, 5), Max: NewVector(5, 5)}, NewVector(5, 5), 2, NewVector(3, 5), true},
+		{"box zero size point", Box{Min: NewVector(5, 5), Max: NewVector(5, 5)}, NewVector(5, 5), 0, NewVector(5, 5), false},
+
+		{"bounds inside", Bounds{Max: NewVector(100, 100)}, NewVector(50, 50), 5, NewVector(50, 50), false},
+		{"bounds outside", Bounds{Max: NewVector(100, 100)}, NewVector(-10, 50), 5, NewVector(5, 50), true},
+		{"bounds boundary", Bounds{Max: NewVector(100, 100)}, NewVector(5, 95), 5, NewVector(5, 95), false},
+		{"bounds past a corner", Bounds{Max: NewVector(100, 100)}, NewVector(120, -3), 5, NewVector(95, 5), true},
+		{"bounds zero size", Bounds{Min: NewVector(5, 5), Max: NewVector(5, 5)}, NewVector(9, 1), 0, NewVector(5, 5), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hit := tt.collider.Push(tt.p, tt.radius)
+			if got.Distance(tt.want) > 1e-9 || hit != tt.hit {
+				t.Errorf("Push(%v, %v) = %v, %v; want %v, %v", tt.p, tt.radius, got, hit, tt.want, tt.hit)
+			}
+		})
+	}
+}
+
+func TestChainCollide(t *testing.T) {
+	circle := Circle{Center: NewVector(150, 0), Radius: 30}
+	box := Box{Min: NewVector(250, -40), Max: NewVector(290, 10)}
+
+	c := NewChain(NewVector(0, 0), 10, 20, math.Pi/4)
+	for i := range c.Len() {
+		spec := c.Spec(i)
+		spec.Radius = 4
+		c.SetSpec(i, spec)
+	}
+	c.SetColliders(circle, box)
+
+	// Drag the body straight through both obstacles. The collision pass is
+	// iterative, so joints may be left a sliver inside.
+	for i := 1; i <= 120; i++ {
+		c.Resolve(NewVector(float64(i)*4, 0), 1.0/60)
+
+		joints := c.Joints()
+		for j, p := range joints {
+			if _, hit := circle.Push(p, 4-1e-3); hit {
+				t.Fatalf("step %d: joint %d at %v is inside the circle, %v from its center", i, j, p, p.Distance(circle.Center))
+			}
+			if _, hit := box.Push(p, 4-1e-3); hit {
+				t.Fatalf("step %d: joint %d at %v is inside the box", i, j, p)
+			}
+			if j > 0 {
+				if got := p.Distance(joints[j-1]); math.Abs(got-20) > 1e-6 {
+					t.Fatalf("step %d: link %d is %v long, want 20", i, j, got)
+				}
+			}
+		}
+	}
+}
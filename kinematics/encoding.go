@@ -0,0 +1,292 @@
+package kinematics
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"animation/internal/binenc"
+)
+
+const (
+	// EncodingVersion is written into every encoded chain. Decoding accepts
+	// this version and older ones.
+	EncodingVersion = 1
+)
+
+// chainMagic starts the binary encoding of a chain
+const chainMagic = "kch"
+
+// Binary flags marking the optional sections of an encoded chain
+const (
+	hasGrowth = 1 << iota
+	hasSpin
+	hasSelfCollision
+	hasPath
+)
+
+// floatsPerJoint is how many float64 the binary encoding stores per joint:
+// position, angle and the seven spec fields
+const floatsPerJoint = 10
+
+// chainJSON is the JSON layout of a chain. Optional state is left out when
+// unused, so the encoding of a plain chain stays short and easy to diff.
+type chainJSON struct {
+	Version       int          `json:"version"`
+	Joints        []Vector     `json:"joints"`
+	Angles        []float64    `json:"angles"`
+	Specs         []JointSpec  `json:"specs"`
+	HalfLife      float64      `json:"halfLife"`
+	SelfCollision *float64     `json:"selfCollision,omitempty"` // Gap, nil when off
+	Growth        []growthJSON `json:"growth,omitempty"`
+	Spin          []float64    `json:"spin,omitempty"`
+	Path          []Vector     `json:"path,omitempty"`
+}
+
+// growthJSON is the JSON layout of a growing link
+type growthJSON struct {
+	Length   float64 `json:"length"`
+	HalfLife float64 `json:"halfLife"`
+}
+
+// MarshalJSON implements json.Marshaler. Both encodings hold the state of the
+// chain: its pose, specs, smoothing, self collision gap, growing links, spring
+// spin and FollowPath trail. The solver and colliders are behavior rather than
+// state; decoding keeps those of the receiving chain, and a zero Chain gets
+// FollowLeader. Joint motion is not kept, a decoded chain starts at rest.
+func (c *Chain) MarshalJSON() ([]byte, error) {
+	v := chainJSON{
+		Version:  EncodingVersion,
+		Joints:   c.joints,
+		Angles:   c.angles,
+		Specs:    c.specs,
+		HalfLife: c.halfLife,
+		Path:     c.path,
+	}
+
+	if c.self != nil {
+		v.SelfCollision = &c.self.gap
+	}
+
+	if c.anyGrowing() {
+		v.Growth = make([]growthJSON, len(c.growth))
+		for i, g := range c.growth {
+			v.Growth[i] = growthJSON{Length: g.length, HalfLife: g.halfLife}
+		}
+	}
+
+	if c.anySpin() {
+		v.Spin = c.spin
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *Chain) UnmarshalJSON(data []byte) error {
+	var v chainJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v.Version < 1 || v.Version > EncodingVersion {
+		return fmt.Errorf("kinematics: unsupported chain version %d", v.Version)
+	}
+
+	growing := make([]growth, len(v.Growth))
+	for i, g := range v.Growth {
+		growing[i] = growth{length: g.Length, halfLife: g.HalfLife}
+	}
+
+	return c.restore(v.Joints, v.Angles, v.Specs, v.HalfLife, v.SelfCollision, growing, v.Spin, v.Path)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is little
+// endian, stores every float in full, and skips the optional sections a chain
+// does not use.
+func (c *Chain) MarshalBinary() ([]byte, error) {
+	return c.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (c *Chain) AppendBinary(b []byte) ([]byte, error) {
+	flags := byte(0)
+	if c.anyGrowing() {
+		flags |= hasGrowth
+	}
+	if c.anySpin() {
+		flags |= hasSpin
+	}
+	if c.self != nil {
+		flags |= hasSelfCollision
+	}
+	if len(c.path) > 0 {
+		flags |= hasPath
+	}
+
+	b = append(b, chainMagic...)
+	b = append(b, EncodingVersion, flags)
+	b = binary.AppendUvarint(b, uint64(len(c.joints)))
+
+	for i, p := range c.joints {
+		s := c.specs[i]
+		b = binenc.AppendFloats(b, p.X, p.Y, c.angles[i],
+			s.Length, s.Min, s.Max, s.Radius, s.Rest, s.Stiffness, s.Damping)
+	}
+	b = binenc.AppendFloats(b, c.halfLife)
+
+	if flags&hasSelfCollision != 0 {
+		b = binenc.AppendFloats(b, c.self.gap)
+	}
+	if flags&hasGrowth != 0 {
+		for _, g := range c.growth {
+			b = binenc.AppendFloats(b, g.length, g.halfLife)
+		}
+	}
+	if flags&hasSpin != 0 {
+		b = binenc.AppendFloats(b, c.spin...)
+	}
+	if flags&hasPath != 0 {
+		b = binary.AppendUvarint(b, uint64(len(c.path)))
+		for _, p := range c.path {
+			b = binenc.AppendFloats(b, p.X, p.Y)
+		}
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (c *Chain) UnmarshalBinary(data []byte) error {
+	d := binenc.NewDecoder(data)
+	if magic := d.Bytes(len(chainMagic)); d.Err() == nil && string(magic) != chainMagic {
+		return fmt.Errorf("kinematics: not an encoded chain")
+	}
+
+	if version := d.Byte(); d.Err() == nil && (version < 1 || version > EncodingVersion) {
+		return fmt.Errorf("kinematics: unsupported chain version %d", version)
+	}
+
+	flags := d.Byte()
+	n := d.Count(floatsPerJoint * 8)
+
+	joints := make([]Vector, n)
+	angles := make([]float64, n)
+	specs := make([]JointSpec, n)
+	for i := 0; i < n; i++ {
+		joints[i] = Vector{X: d.Float(), Y: d.Float()}
+		angles[i] = d.Float()
+		specs[i] = JointSpec{
+			Length: d.Float(), Min: d.Float(), Max: d.Float(), Radius: d.Float(),
+			Rest: d.Float(), Stiffness: d.Float(), Damping: d.Float(),
+		}
+	}
+	halfLife := d.Float()
+
+	var gap *float64
+	if flags&hasSelfCollision != 0 {
+		g := d.Float()
+		gap = &g
+	}
+
+	var growing []growth
+	if flags&hasGrowth != 0 {
+		growing = make([]growth, n)
+		for i := range growing {
+			growing[i] = growth{length: d.Float(), halfLife: d.Float()}
+		}
+	}
+
+	var spin []float64
+	if flags&hasSpin != 0 {
+		spin = make([]float64, n)
+		for i := range spin {
+			spin[i] = d.Float()
+		}
+	}
+
+	var path []Vector
+	if flags&hasPath != 0 {
+		path = make([]Vector, d.Count(16))
+		for i := range path {
+			path[i] = Vector{X: d.Float(), Y: d.Float()}
+		}
+	}
+
+	if err := d.Err(); err != nil {
+		return fmt.Errorf("kinematics: encoded chain is truncated: %w", err)
+	}
+	if rest := d.Rest(); len(rest) > 0 {
+		return fmt.Errorf("kinematics: %d bytes after the encoded chain", len(rest))
+	}
+
+	return c.restore(joints, angles, specs, halfLife, gap, growing, spin, path)
+}
+
+// restore checks decoded state and makes it the state of c. growth and spin
+// may be empty when unused.
+func (c *Chain) restore(joints []Vector, angles []float64, specs []JointSpec, halfLife float64,
+	gap *float64, growing []growth, spin []float64, path []Vector) error {
+	n := len(joints)
+	switch {
+	case n == 0:
+		return fmt.Errorf("kinematics: encoded chain has no joints")
+	case len(angles) != n || len(specs) != n:
+		return fmt.Errorf("kinematics: encoded chain has %d joints, %d angles and %d specs", n, len(angles), len(specs))
+	case len(growing) != 0 && len(growing) != n:
+		return fmt.Errorf("kinematics: encoded chain has %d joints but %d growth entries", n, len(growing))
+	case len(spin) != 0 && len(spin) != n:
+		return fmt.Errorf("kinematics: encoded chain has %d joints but %d spin entries", n, len(spin))
+	}
+
+	if len(growing) == 0 {
+		growing = make([]growth, n)
+	}
+	if len(spin) == 0 {
+		spin = make([]float64, n)
+	}
+
+	c.joints = joints
+	c.angles = angles
+	c.specs = specs
+	c.halfLife = math.Max(halfLife, 0)
+	c.growth = growing
+	c.spin = spin
+	c.path = path
+	c.resetMotion()
+
+	if gap == nil {
+		c.DisableSelfCollision()
+	} else {
+		c.SetSelfCollision(*gap)
+	}
+
+	if c.solver == nil {
+		c.solver = FollowLeader{}
+	}
+
+	return nil
+}
+
+// anyGrowing reports whether any link is still growing
+func (c *Chain) anyGrowing() bool {
+	for i := range c.growth {
+		if c.Growing(i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// anySpin reports whether any spring joint is moving
+func (c *Chain) anySpin() bool {
+	for _, s := range c.spin {
+		if s != 0 {
+			return true
+		}
+	}
+
+	return false
+}
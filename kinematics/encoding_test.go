@@ -0,0 +1,85 @@
+package kinematics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+// encodingRigs returns chains using every optional part of the encoding
+func encodingRigs() map[string]*Chain {
+	plain := NewChain(NewVector(0, 0), 5, 20, math.Pi/4)
+
+	springy := NewChain(NewVector(0, 0), 8, 20, math.Pi/4)
+	springy.SetSprings(120, 4)
+	springy.SetSelfCollision(2)
+	springy.GrowJoint(springy.Len(), 0.5)
+
+	trail := NewChain(NewVector(0, 0), 6, 20, math.Pi/4)
+	trail.SetSolver(FollowPath{})
+
+	for i := 1; i <= 30; i++ {
+		target := NewVector(float64(i)*6, 40*math.Sin(float64(i)/4))
+		for _, c := range []*Chain{plain, springy, trail} {
+			c.Resolve(target, 1.0/60)
+		}
+	}
+
+	return map[string]*Chain{"plain": plain, "springy": springy, "trail": trail}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	for name, c := range encodingRigs() {
+		t.Run(name, func(t *testing.T) {
+			data, err := c.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var back Chain
+			if err := back.UnmarshalBinary(data); err != nil {
+				t.Fatalf("decoding: %v", err)
+			}
+			if again, _ := back.MarshalBinary(); !bytes.Equal(again, data) {
+				t.Errorf("binary changed over a round trip")
+			}
+
+			text, err := json.Marshal(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var fromText Chain
+			if err := json.Unmarshal(text, &fromText); err != nil {
+				t.Fatalf("decoding JSON: %v", err)
+			}
+			if again, _ := fromText.MarshalBinary(); !bytes.Equal(again, data) {
+				t.Errorf("JSON round trip does not match the binary encoding")
+			}
+		})
+	}
+}
+
+func TestEncodingTruncated(t *testing.T) {
+	for name, c := range encodingRigs() {
+		t.Run(name, func(t *testing.T) {
+			data, _ := c.MarshalBinary()
+
+			// Every cut runs out of data, even one inside the magic
+			for n := 0; n < len(data); n++ {
+				var back Chain
+				if err := back.UnmarshalBinary(data[:n]); !errors.Is(err, io.ErrUnexpectedEOF) {
+					t.Fatalf("cut to %d of %d bytes gave %v, want io.ErrUnexpectedEOF", n, len(data), err)
+				}
+			}
+
+			var back Chain
+			if err := back.UnmarshalBinary(append(data, 0)); err == nil {
+				t.Errorf("decoded with a byte left over")
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package kinematics
+
+// Fabrik reaches the head (first joint) toward the target while the tail
+// (last joint) stays pinned at Anchor, using forward and backward reaching
+// inverse kinematics. Links keep their spec length and bend range. Zero
+// Iterations and Tolerance use DefaultIterations and DefaultTolerance.
+type Fabrik struct {
+	Anchor     Vector
+	Iterations int
+	Tolerance  float64
+}
+
+// Solve implements Solver
+func (f Fabrik) Solve(c *Chain, target Vector, _ float64) Result {
+	iterations, tolerance := withDefaults(f.Iterations, f.Tolerance)
+	return c.fabrik(target, f.Anchor, iterations, tolerance)
+}
+
+// FabrikResolve runs a default Fabrik solve from anchor, whatever solver the
+// chain is configured with. It returns the remaining distance between head
+// and target, which stays above zero when the target is out of reach.
+func (c *Chain) FabrikResolve(target, anchor Vector) float64 {
+	return Fabrik{Anchor: anchor}.Solve(c, target, 0).Error
+}
+
+func (c *Chain) fabrik(target, anchor Vector, iterations int, tolerance float64) Result {
+	last := len(c.joints) - 1
+	if last == 0 {
+		c.joints[0] = anchor
+		return Result{Error: anchor.Distance(target)}
+	}
+
+	iter := 0
+	for iter < iterations {
+		iter++
+
+		// Forward pass: put the head on the target and pull the rest after it
+		c.joints[0] = target
+		for i := 1; i <= last; i++ {
+			c.fabrikLink(i, i-1, i)
+		}
+
+		// Backward pass: pin the tail to the anchor and push the rest back out
+		c.joints[last] = anchor
+		for i := last - 1; i >= 0; i-- {
+			c.fabrikLink(i, i+1, i+1)
+		}
+
+		if c.joints[0].DistanceSquared(target) <= tolerance*tolerance {
+			break
+		}
+	}
+
+	// The head faces along its link, there is nothing further to point at
+	c.angles[0] = c.angles[1]
+
+	return Result{Iterations: iter, Error: c.joints[0].Distance(target)}
+}
+
+// fabrikLink moves joint i to its link length from the already placed joint from.
+// link is the index of the angle for the link between them, which is
+// constrained against the neighbouring link already placed on that side.
+func (c *Chain) fabrikLink(i, from, link int) {
+	// angles[link] is the heading from joint link toward joint link-1
+	var heading float64
+	if from < i {
+		heading = c.joints[from].Subtract(c.joints[i]).Angle()
+	} else {
+		heading = c.joints[i].Subtract(c.joints[from]).Angle()
+	}
+
+	// Constrain against the link placed just before this one in the pass
+	switch {
+	case from < i && link > 1:
+		heading = c.bend(link, heading)
+	case from > i && link < len(c.joints)-1:
+		heading = c.bendBack(link, heading)
+	default:
+		heading = SimplifyAngle(heading)
+	}
+
+	c.angles[link] = heading
+
+	offset := FromAngle(heading).Multiply(c.specs[link].Length)
+	if from < i {
+		c.joints[i] = c.joints[from].Subtract(offset)
+	} else {
+		c.joints[i] = c.joints[from].Add(offset)
+	}
+}
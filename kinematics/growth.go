@@ -0,0 +1,58 @@
+package kinematics
+
+import "math"
+
+// growth eases a link from zero up to its rest length
+type growth struct {
+	length   float64 // Rest length the link is growing toward, 0 when not growing
+	halfLife float64 // Seconds to close half the gap to the rest length
+}
+
+// GrowJoint inserts a joint at index like InsertJoint, but the link it adds
+// starts at zero length and eases up to its spec length over the following
+// Resolve calls, closing half the gap every halfLife seconds.
+func (c *Chain) GrowJoint(index int, halfLife float64) {
+	index = max(0, min(index, len(c.joints)))
+	c.InsertJoint(index)
+	if len(c.joints) == 1 {
+		return
+	}
+
+	// A new head grows the link to the old head, any other joint its own link
+	link := max(1, min(index, len(c.joints)-1))
+
+	// The link keeps the heading InsertJoint gave it, along its neighbours,
+	// until it is long enough to be measured
+	c.growth[link] = growth{length: c.specs[link].Length, halfLife: halfLife}
+	c.specs[link].Length = 0
+
+	c.Resolve(c.joints[0], 0)
+	c.rest(index)
+}
+
+// Growing reports whether the link of joint i is still growing
+func (c *Chain) Growing(i int) bool {
+	return c.growth[i].length > 0
+}
+
+// grow moves every growing link dt seconds closer to its rest length
+func (c *Chain) grow(dt float64) {
+	for i := range c.growth {
+		g := &c.growth[i]
+		if g.length == 0 {
+			continue
+		}
+
+		length := g.length
+		if g.halfLife > 0 {
+			length = c.specs[i].Length + (g.length-c.specs[i].Length)*(1-math.Exp2(-dt/g.halfLife))
+		}
+
+		if math.Abs(g.length-length) < 0.01 {
+			length = g.length
+			*g = growth{}
+		}
+
+		c.specs[i].Length = length
+	}
+}
@@ -0,0 +1,135 @@
+package kinematics
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// straightChain returns a chain of n joints, links of 20, swum straight up
+// until the whole body trails in a line below the head
+func straightChain(n int) *Chain {
+	c := NewChain(NewVector(0, 0), n, 20, math.Pi/8)
+	c.SetHalfLife(0)
+	for i := 1; i <= 100; i++ {
+		c.Resolve(NewVector(0, -float64(i)*5), 1.0/60)
+	}
+
+	return c
+}
+
+func TestInsertJoint(t *testing.T) {
+	for _, index := range []int{0, 3, 6} {
+		c := straightChain(6)
+		joints := slices.Clone(c.Joints())
+		head := c.Head()
+
+		c.InsertJoint(index)
+		if c.Len() != 7 {
+			t.Fatalf("insert at %d: %d joints, want 7", index, c.Len())
+		}
+		checkLinks(t, c)
+
+		var want Vector
+		switch index {
+		case 0:
+			want = head.Add(NewVector(0, -20))
+		case 6:
+			want = joints[5].Add(NewVector(0, 20))
+		default:
+			// It starts halfway and is pushed out to its link length
+			want = joints[index-1].Add(NewVector(0, 20))
+		}
+		if got := c.Joints()[index]; got.Distance(want) > 1e-9 {
+			t.Errorf("insert at %d: new joint at %v, want %v", index, got, want)
+		}
+	}
+
+	// A lone head grows a tail from its own template
+	c := NewChainFromSpecs(NewVector(0, 0), []JointSpec{{Length: 7}})
+	c.InsertJoint(1)
+	if c.Len() != 2 || c.Joints()[1].Distance(c.Head()) != 7 {
+		t.Errorf("a lone head grew %d joints, the tail %v away", c.Len(), c.Joints()[1].Distance(c.Head()))
+	}
+}
+
+func TestRemoveJoint(t *testing.T) {
+	for _, index := range []int{0, 3, 5} {
+		c := straightChain(6)
+		joints := slices.Clone(c.Joints())
+
+		if !c.RemoveJoint(index) || c.Len() != 5 {
+			t.Fatalf("remove at %d: %d joints, want 5", index, c.Len())
+		}
+		checkLinks(t, c)
+
+		// The head stays put unless it was removed
+		want := joints[0]
+		if index == 0 {
+			want = joints[1]
+		}
+		if c.Head() != want {
+			t.Errorf("remove at %d: head at %v, want %v", index, c.Head(), want)
+		}
+	}
+
+	c := straightChain(2)
+	for _, index := range []int{-1, 2} {
+		if c.RemoveJoint(index) {
+			t.Errorf("removed joint %d of a 2 joint chain", index)
+		}
+	}
+	if !c.RemoveJoint(1) || c.RemoveJoint(0) || c.Len() != 1 {
+		t.Errorf("chain went down to %d joints, want a lone head that stays", c.Len())
+	}
+
+	c = straightChain(4)
+	if !c.DeleteJoint() || c.DeleteJoint() || c.Len() != 3 {
+		t.Errorf("DeleteJoint left %d joints, want 3", c.Len())
+	}
+}
+
+func TestGrowJoint(t *testing.T) {
+	for _, index := range []int{0, 3, 6} {
+		c := straightChain(6)
+		c.GrowJoint(index, 0.25)
+
+		// A new head grows the link to the old head, any other joint its own link
+		link := max(index, 1)
+		if !c.Growing(link) || c.specs[link].Length != 0 || c.Spec(link).Length != 20 {
+			t.Fatalf("grow at %d: link %d is %v long growing to %v, want 0 growing to 20",
+				index, link, c.specs[link].Length, c.Spec(link).Length)
+		}
+
+		// Half the length in one half-life, then all of it
+		head := c.Head()
+		for step := 1; step <= 480; step++ {
+			c.Resolve(head, 1.0/120)
+
+			// Links are the length they have grown to so far
+			joints := c.Joints()
+			for i := 1; i < len(joints); i++ {
+				if got, want := joints[i].Distance(joints[i-1]), c.specs[i].Length; math.Abs(got-want) > 1e-9 {
+					t.Fatalf("grow at %d, step %d: link %d is %v long, want %v", index, step, i, got, want)
+				}
+			}
+
+			// The body stays straight while the link grows
+			for i, p := range c.Joints() {
+				if math.Abs(p.X) > 1e-9 {
+					t.Fatalf("grow at %d, step %d: joint %d at %v is off the line", index, step, i, p)
+				}
+			}
+
+			if step == 30 {
+				if got := c.specs[link].Length; math.Abs(got-10) > 1e-9 {
+					t.Errorf("grow at %d: link is %v long after one half-life, want 10", index, got)
+				}
+			}
+		}
+
+		if c.Growing(link) || c.specs[link].Length != 20 {
+			t.Errorf("grow at %d: link is %v long, still growing %v; want 20 and done", index, c.specs[link].Length, c.Growing(link))
+		}
+	}
+}
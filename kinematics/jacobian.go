@@ -0,0 +1,99 @@
+package kinematics
+
+const (
+	// DefaultDamping is the damping DampedLeastSquares uses when none is set
+	DefaultDamping = 10.0
+)
+
+// DampedLeastSquares reaches the head toward the target with the damped
+// least squares Jacobian method. The tail stays pinned at Anchor and every
+// joint turns at once by the amount that best reduces the head error. Damping
+// keeps the steps small near singular poses, such as a fully stretched chain.
+// Turns are limited by the joint bend ranges. Zero fields use DefaultIterations,
+// DefaultTolerance and DefaultDamping.
+type DampedLeastSquares struct {
+	Anchor     Vector
+	Iterations int
+	Tolerance  float64
+	Damping    float64
+}
+
+// Solve implements Solver
+func (s DampedLeastSquares) Solve(c *Chain, target Vector, _ float64) Result {
+	iterations, tolerance := withDefaults(s.Iterations, s.Tolerance)
+	damping := s.Damping
+	if damping <= 0 {
+		damping = DefaultDamping
+	}
+
+	last := len(c.joints) - 1
+	if last == 0 {
+		c.joints[0] = s.Anchor
+		return Result{Error: s.Anchor.Distance(target)}
+	}
+
+	c.measureAngles()
+	c.layoutFrom(s.Anchor)
+
+	// Column j of the Jacobian is how the head moves when joint j turns
+	jacobian := make([]Vector, last+1)
+	turns := make([]float64, last+1)
+
+	step := c.Length() / float64(last)
+
+	iter := 0
+	for iter < iterations {
+		e := target.Subtract(c.joints[0])
+		if e.MagnitudeSquared() <= tolerance*tolerance {
+			break
+		}
+
+		iter++
+
+		// Large errors linearize badly, so step about one link toward the target
+		if e.MagnitudeSquared() > step*step {
+			e = e.SetMag(step)
+		}
+
+		// J * J^T + damping^2 * I, a symmetric 2x2 matrix
+		a, b, d := damping*damping, 0.0, damping*damping
+		for j := 1; j <= last; j++ {
+			r := c.joints[0].Subtract(c.joints[j])
+			jacobian[j] = Vector{X: -r.Y, Y: r.X}
+			a += jacobian[j].X * jacobian[j].X
+			b += jacobian[j].X * jacobian[j].Y
+			d += jacobian[j].Y * jacobian[j].Y
+		}
+
+		det := a*d - b*b
+		if det == 0 {
+			break
+		}
+
+		// f = (J * J^T + damping^2 * I)^-1 * e, then each turn is J^T * f
+		f := Vector{
+			X: (d*e.X - b*e.Y) / det,
+			Y: (a*e.Y - b*e.X) / det,
+		}
+		for j := 1; j <= last; j++ {
+			turns[j] = jacobian[j].Dot(f)
+		}
+
+		// Turning joint j swings links 1..j, so link i turns by the sum of
+		// the turns from joint i back to the tail
+		sum := 0.0
+		for i := last; i >= 1; i-- {
+			sum += turns[i]
+			c.angles[i] = SimplifyAngle(c.angles[i] + sum)
+		}
+
+		// Enforce the limits from the tail forward, each against the link behind it
+		for i := last - 1; i >= 1; i-- {
+			c.angles[i] = c.bendBack(i, c.angles[i])
+		}
+
+		c.layoutFrom(s.Anchor)
+	}
+
+	return Result{Iterations: iter, Error: c.joints[0].Distance(target)}
+}
@@ -0,0 +1,64 @@
+package kinematics
+
+// motion is how a joint moved over the last Resolve that took time
+type motion struct {
+	last         Vector  // Position after that Resolve
+	lastAngle    float64 // Heading after that Resolve
+	velocity     Vector
+	acceleration Vector
+	spin         float64 // Change of heading, in radians per second
+	measured     bool    // velocity holds a real measurement, so acceleration can be taken
+}
+
+// Velocity returns how fast joint i moved over the last Resolve or Attach
+// with a dt above zero, in units per second. Calls with a dt of 0 add their
+// movement to the next timed call, so extra passes in a frame are not lost.
+func (c *Chain) Velocity(i int) Vector {
+	return c.motion[i].velocity
+}
+
+// Acceleration returns how much the velocity of joint i changed per second
+// over the last timed Resolve, zero until it has been measured twice
+func (c *Chain) Acceleration(i int) Vector {
+	return c.motion[i].acceleration
+}
+
+// AngularVelocity returns how fast the heading of joint i turned over the
+// last timed Resolve, in radians per second, positive toward larger headings
+func (c *Chain) AngularVelocity(i int) float64 {
+	return c.motion[i].spin
+}
+
+// track measures the motion of every joint since the last timed call
+func (c *Chain) track(dt float64) {
+	if dt <= 0 {
+		return
+	}
+
+	for i := range c.motion {
+		m := &c.motion[i]
+		velocity := c.joints[i].Subtract(m.last).Divide(dt)
+		if m.measured {
+			m.acceleration = velocity.Subtract(m.velocity).Divide(dt)
+		}
+
+		m.velocity = velocity
+		m.spin = angleDelta(m.lastAngle, c.angles[i]) / dt
+		m.last = c.joints[i]
+		m.lastAngle = c.angles[i]
+		m.measured = true
+	}
+}
+
+// resetMotion puts every joint at rest where it is now
+func (c *Chain) resetMotion() {
+	c.motion = make([]motion, len(c.joints))
+	for i := range c.motion {
+		c.rest(i)
+	}
+}
+
+// rest puts joint i at rest where it is now
+func (c *Chain) rest(i int) {
+	c.motion[i] = motion{last: c.joints[i], lastAngle: c.angles[i]}
+}
@@ -0,0 +1,136 @@
+package kinematics
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// climb moves the head of a straight chain up by step for frames frames of dt
+func climb(c *Chain, step float64, frames int, dt float64) {
+	for range frames {
+		c.Resolve(c.Head().Add(NewVector(0, -step)), dt)
+	}
+}
+
+// checkVelocity fails t unless every joint of c moves at want
+func checkVelocity(t *testing.T, c *Chain, want Vector) {
+	t.Helper()
+
+	for i := range c.Len() {
+		if got := c.Velocity(i); got.Distance(want) > 1e-6 {
+			t.Errorf("joint %d moves at %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestConstantVelocity(t *testing.T) {
+	c := straightChain(6)
+
+	climb(c, 5, 10, 1.0/60)
+	checkVelocity(t, c, NewVector(0, -300))
+	for i := range c.Len() {
+		if got := c.Acceleration(i); got.Magnitude() > 1e-6 {
+			t.Errorf("joint %d accelerates at %v, want 0", i, got)
+		}
+		if got := c.AngularVelocity(i); math.Abs(got) > 1e-9 {
+			t.Errorf("joint %d turns at %v, want 0", i, got)
+		}
+	}
+
+	// A new chain has one measurement at most, so no acceleration yet
+	c = NewChain(NewVector(0, 0), 3, 20, math.Pi/8)
+	c.SetHalfLife(0)
+	climb(c, 5, 1, 1.0/60)
+	if got := c.Acceleration(0); got != (Vector{}) {
+		t.Errorf("head accelerates at %v after one step, want 0", got)
+	}
+}
+
+func TestUntimedResolveFolds(t *testing.T) {
+	c := straightChain(6)
+	climb(c, 5, 10, 1.0/60)
+
+	// Two passes in one frame count as one move over the frame
+	climb(c, 5, 1, 0)
+	checkVelocity(t, c, NewVector(0, -300))
+	climb(c, 5, 1, 1.0/60)
+	checkVelocity(t, c, NewVector(0, -600))
+
+	climb(c, 5, 1, 1.0/60)
+	checkVelocity(t, c, NewVector(0, -300))
+}
+
+func TestAngularVelocity(t *testing.T) {
+	c := NewChain(NewVector(100, 0), 4, 10, math.Pi/4)
+	c.SetHalfLife(0)
+
+	// Circle the head at one radian a second
+	for frame := 1; frame <= 120; frame++ {
+		at := float64(frame) / 60
+		c.Resolve(NewVector(100*math.Cos(at), 100*math.Sin(at)), 1.0/60)
+	}
+
+	if got := c.AngularVelocity(0); math.Abs(got-1) > 1e-9 {
+		t.Errorf("head turns at %v, want 1", got)
+	}
+}
+
+func TestMotionFollowsJoints(t *testing.T) {
+	// checkMoved fails t unless each joint moved from where from says it was
+	checkMoved := func(t *testing.T, c *Chain, from func(i int) Vector) {
+		t.Helper()
+
+		for i, p := range c.Joints() {
+			if got, want := c.Velocity(i), p.Subtract(from(i)).Multiply(60); got.Distance(want) > 1e-6 {
+				t.Errorf("joint %d moves at %v, want %v", i, got, want)
+			}
+		}
+	}
+
+	for _, index := range []int{0, 3, 6} {
+		c := straightChain(6)
+		climb(c, 5, 10, 1.0/60)
+		before := slices.Clone(c.Joints())
+
+		// The joints keep their motion, the new one starts at rest
+		c.InsertJoint(index)
+		added := c.Joints()[index]
+		for i := range c.Len() {
+			want := NewVector(0, -300)
+			if i == index {
+				want = Vector{}
+			}
+			if got := c.Velocity(i); got.Distance(want) > 1e-6 {
+				t.Errorf("insert at %d: joint %d moves at %v, want %v", index, i, got, want)
+			}
+		}
+
+		climb(c, 5, 1, 1.0/60)
+		checkMoved(t, c, func(i int) Vector {
+			switch {
+			case i < index:
+				return before[i]
+			case i == index:
+				return added
+			default:
+				return before[i-1]
+			}
+		})
+	}
+
+	for _, index := range []int{0, 3, 5} {
+		c := straightChain(6)
+		climb(c, 5, 10, 1.0/60)
+		before := slices.Clone(c.Joints())
+
+		c.RemoveJoint(index)
+		climb(c, 5, 1, 1.0/60)
+		checkMoved(t, c, func(i int) Vector {
+			if i < index {
+				return before[i]
+			}
+			return before[i+1]
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package kinematics
+
+import "slices"
+
+// FollowPath drags the head toward the target like FollowLeader, but the
+// rest of the chain does not cut corners: the head records where it has been
+// and every joint is placed on that trail at its distance along the body from
+// the head, so the body passes exactly through the past positions of the head
+// like a real snake. Bend ranges and springs are not applied.
+type FollowPath struct {
+	// Spacing is the least distance between two recorded head positions,
+	// 0 = a quarter of the shortest link
+	Spacing float64
+}
+
+// Solve implements Solver
+func (f FollowPath) Solve(c *Chain, target Vector, dt float64) Result {
+	c.followPath(target, dt, f.Spacing)
+	return Result{Iterations: 1, Error: c.joints[0].Distance(target)}
+}
+
+// Path returns the head positions recorded by FollowPath, oldest first. The
+// slice must not be modified.
+func (c *Chain) Path() []Vector {
+	return c.path
+}
+
+// ClearPath forgets the recorded trail, the next FollowPath Resolve starts a
+// new one from the current pose
+func (c *Chain) ClearPath() {
+	c.path = c.path[:0]
+}
+
+// followPath moves the head toward pos, records it, and lays the body along the trail
+func (c *Chain) followPath(pos Vector, dt, spacing float64) {
+	c.moveHead(pos, dt)
+
+	if spacing <= 0 {
+		spacing = c.shortestLink() / 4
+	}
+
+	if len(c.path) == 0 {
+		// Start the trail along the current pose, tail first
+		for i := len(c.joints) - 1; i >= 0; i-- {
+			c.path = append(c.path, c.joints[i])
+		}
+	}
+
+	// The newest point always sits on the head, older ones are kept spacing apart
+	last := len(c.path) - 1
+	if last > 0 && c.path[last-1].Distance(c.joints[0]) < spacing {
+		c.path[last] = c.joints[0]
+	} else {
+		c.path = append(c.path, c.joints[0])
+	}
+
+	c.placeOnPath()
+	c.measureAngles()
+}
+
+// placeOnPath puts every joint on the trail at its arc length from the head,
+// continuing the oldest segment straight if the trail is too short, then drops
+// the part of the trail behind the tail
+func (c *Chain) placeOnPath() {
+	k := len(c.path) - 1
+	walked := 0.0 // Arc length from the head to c.path[k]
+	along := 0.0  // Arc length from the head to the joint being placed
+
+	for i := 1; i < len(c.joints); i++ {
+		along += c.specs[i].Length
+
+		for k > 0 {
+			step := c.path[k].Distance(c.path[k-1])
+			if walked+step >= along {
+				break
+			}
+
+			walked += step
+			k--
+		}
+
+		if k == 0 {
+			// Past the end of the trail, carry on along the oldest segment
+			from := c.joints[i-1]
+			dir := c.path[0].Subtract(from)
+			if len(c.path) > 1 {
+				dir = c.path[0].Subtract(c.path[1])
+			}
+			if dir.MagnitudeSquared() == 0 {
+				dir = NewVector(0, 1)
+			}
+
+			c.joints[i] = from.Add(dir.SetMag(c.specs[i].Length))
+			continue
+		}
+
+		step := c.path[k].Distance(c.path[k-1])
+		t := 0.0
+		if step > 0 {
+			t = (along - walked) / step
+		}
+		c.joints[i] = c.path[k].Lerp(c.path[k-1], t)
+	}
+
+	// Keep one point behind the tail so it still has a segment to sit on
+	if k > 1 {
+		c.path = slices.Delete(c.path, 0, k-1)
+	}
+}
+
+// shortestLink returns the length of the shortest link, or 1 for a chain with
+// no links
+func (c *Chain) shortestLink() float64 {
+	shortest := 0.0
+	for i := 1; i < len(c.specs); i++ {
+		if shortest == 0 || c.specs[i].Length < shortest {
+			shortest = c.specs[i].Length
+		}
+	}
+
+	if shortest <= 0 {
+		return 1
+	}
+
+	return shortest
+}
@@ -0,0 +1,326 @@
+package kinematics
+
+import (
+	"math"
+	"slices"
+)
+
+// Particle is a point mass in a System. Its velocity is implied by how far it
+// moved since Prev, as in VerletChain.
+type Particle struct {
+	Pos     Vector
+	Prev    Vector  // Position before the last step
+	InvMass float64 // 1 / mass, 0 = immovable
+	Force   Vector  // Acceleration on top of Gravity over the next Step, which clears it
+}
+
+// Constraint is a rule a System keeps its particles to
+type Constraint interface {
+	// Project moves the particles toward satisfying the constraint, sharing
+	// the correction by inverse mass. Particles with InvMass 0 do not move.
+	Project(p []Particle)
+}
+
+// System is a position based dynamics solver. Particles are integrated with
+// verlet steps, then every constraint is projected in the order it was added,
+// Iterations times over. Chains, ropes, cloth and soft bodies are all particles
+// joined by distance, angle and bending constraints.
+type System struct {
+	particles   []Particle
+	constraints []Constraint
+
+	Gravity    Vector  // Acceleration applied to every movable particle
+	Drag       float64 // Rate velocity decays at, it keeps exp(-Drag * dt) of itself each step
+	Iterations int     // Projection passes per Step, 0 = DefaultIterations
+}
+
+// NewSystem creates an empty system with the same gravity and drag as a VerletChain
+func NewSystem() *System {
+	return &System{
+		Gravity: NewVector(0, DefaultGravity),
+		Drag:    0.5,
+	}
+}
+
+// AddParticle adds a particle at rest at pos and returns its index
+func (s *System) AddParticle(pos Vector, invMass float64) int {
+	s.particles = append(s.particles, Particle{Pos: pos, Prev: pos, InvMass: invMass})
+	return len(s.particles) - 1
+}
+
+// Add adds constraints, projected after the ones already added. Constraints
+// added as pointers can be changed between steps, for example to move a Pin.
+func (s *System) Add(constraints ...Constraint) {
+	s.constraints = append(s.constraints, constraints...)
+}
+
+// Remove takes a constraint added as a pointer out of the system
+func (s *System) Remove(c Constraint) {
+	if i := slices.Index(s.constraints, c); i >= 0 {
+		s.constraints = slices.Delete(s.constraints, i, i+1)
+	}
+}
+
+// Particles returns the particles in the order they were added. Moving a
+// particle here teleports it with its velocity unless Prev is moved as well.
+func (s *System) Particles() []Particle {
+	return s.particles
+}
+
+// Constraints returns the constraints in projection order. The slice must not be modified.
+func (s *System) Constraints() []Constraint {
+	return s.constraints
+}
+
+// Positions appends the position of every particle to dst and returns it
+func (s *System) Positions(dst []Vector) []Vector {
+	for _, p := range s.particles {
+		dst = append(dst, p.Pos)
+	}
+
+	return dst
+}
+
+// Step integrates dt seconds and then solves the constraints. Immovable
+// particles are left where they are, with the velocity of the last move made
+// to them by hand.
+func (s *System) Step(dt float64) {
+	if dt > 0 {
+		damping := math.Exp(-s.Drag * dt)
+		for i := range s.particles {
+			p := &s.particles[i]
+			if p.InvMass == 0 {
+				p.Force = Vector{}
+				continue
+			}
+
+			velocity := p.Pos.Subtract(p.Prev).Multiply(damping)
+			accel := s.Gravity.Add(p.Force)
+
+			p.Prev = p.Pos
+			p.Pos = p.Pos.Add(velocity).Add(accel.Multiply(dt * dt))
+			p.Force = Vector{}
+		}
+	}
+
+	iterations := s.Iterations
+	if iterations <= 0 {
+		iterations = DefaultIterations
+	}
+
+	s.Solve(iterations)
+}
+
+// Solve projects every constraint iterations times, without integrating
+func (s *System) Solve(iterations int) {
+	for iter := 0; iter < iterations; iter++ {
+		for _, c := range s.constraints {
+			c.Project(s.particles)
+		}
+	}
+}
+
+// stiffness returns the share of the error a constraint corrects per pass
+func stiffness(k float64) float64 {
+	if k <= 0 || k > 1 {
+		return 1
+	}
+
+	return k
+}
+
+// Distance keeps particles A and B between Min and Max apart. With one of
+// them immovable it matches ConstrainDistance, with equal masses
+// ConstrainDistanceSymmetric.
+type Distance struct {
+	A, B      int
+	Min, Max  float64
+	Stiffness float64 // Share of the error corrected per pass, 0 = 1 = rigid
+}
+
+// Project implements Constraint
+func (d *Distance) Project(p []Particle) {
+	a, b := &p[d.A], &p[d.B]
+	w := a.InvMass + b.InvMass
+	if w == 0 {
+		return
+	}
+
+	diff := b.Pos.Subtract(a.Pos)
+	dist := diff.Magnitude()
+
+	var target float64
+	switch {
+	case dist < d.Min:
+		target = d.Min
+	case dist > d.Max:
+		target = d.Max
+	default:
+		return
+	}
+
+	if dist == 0 {
+		diff, dist = Vector{X: 1}, 1
+	}
+
+	correction := diff.Multiply((dist - target) / dist * stiffness(d.Stiffness) / w)
+	a.Pos = a.Pos.Add(correction.Multiply(a.InvMass))
+	b.Pos = b.Pos.Subtract(correction.Multiply(b.InvMass))
+}
+
+// Angle keeps the turn at B, from the heading of A toward B to the heading
+// of B toward C, between Min and Max. It is the bend range of a JointSpec
+// with A, B and C as consecutive joints running from the head to the tail.
+type Angle struct {
+	A, B, C   int
+	Min, Max  float64
+	Stiffness float64 // Share of the error corrected per pass, 0 = 1 = rigid
+}
+
+// Project implements Constraint
+func (c *Angle) Project(p []Particle) {
+	a, b, cc := &p[c.A], &p[c.B], &p[c.C]
+
+	in := b.Pos.Subtract(a.Pos)
+	out := cc.Pos.Subtract(b.Pos)
+	if in.MagnitudeSquared() == 0 || out.MagnitudeSquared() == 0 {
+		return
+	}
+
+	turn := RelativeAngleDiff(in.Angle(), out.Angle())
+	clamped := math.Max(c.Min, math.Min(c.Max, turn))
+	if turn == clamped {
+		return
+	}
+
+	// How the turn changes as each particle moves, a sideways push on either
+	// end and the opposite on B, so the correction keeps the momentum
+	gradA := Vector{X: -in.Y, Y: in.X}.Divide(in.MagnitudeSquared())
+	gradC := Vector{X: -out.Y, Y: out.X}.Divide(out.MagnitudeSquared())
+	gradB := gradA.Add(gradC).Multiply(-1)
+
+	w := a.InvMass*gradA.MagnitudeSquared() + b.InvMass*gradB.MagnitudeSquared() + cc.InvMass*gradC.MagnitudeSquared()
+	if w == 0 {
+		return
+	}
+
+	lambda := (clamped - turn) / w * stiffness(c.Stiffness)
+	a.Pos = a.Pos.Add(gradA.Multiply(lambda * a.InvMass))
+	b.Pos = b.Pos.Add(gradB.Multiply(lambda * b.InvMass))
+	cc.Pos = cc.Pos.Add(gradC.Multiply(lambda * cc.InvMass))
+}
+
+// Bending keeps B at Rest from the centroid of A, B and C. A Rest of 0
+// straightens the three, which gives ropes and cloth their stiffness without
+// an explicit angle.
+type Bending struct {
+	A, B, C   int
+	Rest      float64
+	Stiffness float64 // Share of the error corrected per pass, 0 = 1 = rigid
+}
+
+// NewBending creates a bending constraint resting in the current pose of p
+func NewBending(p []Particle, a, b, c int, stiffness float64) *Bending {
+	center := p[a].Pos.Add(p[b].Pos).Add(p[c].Pos).Divide(3)
+	return &Bending{A: a, B: b, C: c, Rest: p[b].Pos.Distance(center), Stiffness: stiffness}
+}
+
+// Project implements Constraint
+func (c *Bending) Project(p []Particle) {
+	a, b, cc := &p[c.A], &p[c.B], &p[c.C]
+	w := a.InvMass + 2*b.InvMass + cc.InvMass
+	if w == 0 {
+		return
+	}
+
+	center := a.Pos.Add(b.Pos).Add(cc.Pos).Divide(3)
+	dir := b.Pos.Subtract(center)
+	dist := dir.Magnitude()
+	if dist == 0 {
+		return
+	}
+
+	correction := dir.Multiply((1 - c.Rest/dist) * stiffness(c.Stiffness))
+	a.Pos = a.Pos.Add(correction.Multiply(2 * a.InvMass / w))
+	b.Pos = b.Pos.Subtract(correction.Multiply(4 * b.InvMass / w))
+	cc.Pos = cc.Pos.Add(correction.Multiply(2 * cc.InvMass / w))
+}
+
+// Area keeps the polygon through particles P, in order and closed, enclosing
+// Rest. The area is signed, positive when the outline runs the way Ring lays
+// its joints out. Every particle is moved along the area gradient at once,
+// which spreads the correction over the whole outline like pressure.
+type Area struct {
+	P         []int
+	Rest      float64
+	Stiffness float64 // Share of the error corrected per pass, 0 = 1 = incompressible
+
+	moved []Vector
+}
+
+// Project implements Constraint
+func (c *Area) Project(p []Particle) {
+	n := len(c.P)
+	if n < 3 {
+		return
+	}
+
+	area := 0.0
+	for i, k := range c.P {
+		area += p[k].Pos.Cross(p[c.P[(i+1)%n]].Pos)
+	}
+	area /= 2
+
+	// The gradient at each particle is half its neighbours' difference turned a quarter
+	gradient := func(i int) Vector {
+		prev, next := p[c.P[(i+n-1)%n]].Pos, p[c.P[(i+1)%n]].Pos
+		return Vector{X: next.Y - prev.Y, Y: prev.X - next.X}.Divide(2)
+	}
+
+	w := 0.0
+	for i, k := range c.P {
+		w += p[k].InvMass * gradient(i).MagnitudeSquared()
+	}
+	if w == 0 {
+		return
+	}
+
+	lambda := (c.Rest - area) / w * stiffness(c.Stiffness)
+	c.moved = c.moved[:0]
+	for i, k := range c.P {
+		c.moved = append(c.moved, p[k].Pos.Add(gradient(i).Multiply(lambda*p[k].InvMass)))
+	}
+
+	for i, k := range c.P {
+		p[k].Pos = c.moved[i]
+	}
+}
+
+// Pin holds particle P at Pos, whatever its mass. Give pinned particles an
+// InvMass of 0 so later constraints do not pull them off the pin. Moving Pos
+// between steps drags the rest of the system along.
+type Pin struct {
+	P   int
+	Pos Vector
+}
+
+// Project implements Constraint
+func (c *Pin) Project(p []Particle) {
+	p[c.P].Pos = c.Pos
+}
+
+// Collision keeps particle P, a ball of the given Radius, out of a Collider
+type Collision struct {
+	P        int
+	Radius   float64
+	Collider Collider
+}
+
+// Project implements Constraint
+func (c *Collision) Project(p []Particle) {
+	if p[c.P].InvMass == 0 {
+		return
+	}
+
+	p[c.P].Pos, _ = c.Collider.Push(p[c.P].Pos, c.Radius)
+}
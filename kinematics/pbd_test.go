@@ -0,0 +1,239 @@
+package kinematics
+
+import (
+	"math"
+	"testing"
+)
+
+// particles returns particles at rest on points, all of unit mass
+func particles(points ...Vector) []Particle {
+	p := make([]Particle, len(points))
+	for i, pos := range points {
+		p[i] = Particle{Pos: pos, Prev: pos, InvMass: 1}
+	}
+
+	return p
+}
+
+func TestDistanceProject(t *testing.T) {
+	a, b := NewVector(0, 0), NewVector(30, 40)
+
+	p := particles(a, b)
+	(&Distance{A: 0, B: 1, Min: 20, Max: 20}).Project(p)
+	wantA, wantB := ConstrainDistanceSymmetric(a, b, 20, 20)
+	if p[0].Pos.Distance(wantA) > 1e-9 || p[1].Pos.Distance(wantB) > 1e-9 {
+		t.Errorf("equal masses moved to %v %v, want %v %v", p[0].Pos, p[1].Pos, wantA, wantB)
+	}
+
+	p = particles(a, b)
+	p[0].InvMass = 0
+	(&Distance{A: 0, B: 1, Min: 20, Max: 20}).Project(p)
+	if want := ConstrainDistance(a, b, 20, 20); p[0].Pos != a || p[1].Pos.Distance(want) > 1e-9 {
+		t.Errorf("with A immovable moved to %v %v, want %v %v", p[0].Pos, p[1].Pos, a, want)
+	}
+
+	p = particles(a, b)
+	(&Distance{A: 0, B: 1, Min: 40, Max: 60}).Project(p)
+	if p[0].Pos != a || p[1].Pos != b {
+		t.Errorf("particles in range moved to %v %v", p[0].Pos, p[1].Pos)
+	}
+
+	p = particles(a, b)
+	(&Distance{A: 0, B: 1, Min: 100, Max: 100, Stiffness: 0.5}).Project(p)
+	if got := p[0].Pos.Distance(p[1].Pos); math.Abs(got-75) > 1e-9 {
+		t.Errorf("half stiffness left them %v apart, want 75", got)
+	}
+}
+
+func TestAngleProject(t *testing.T) {
+	// A right angle turn, against a range of 30 degrees either way
+	a, b, c := NewVector(0, 0), NewVector(10, 0), NewVector(10, 10)
+	angle := &Angle{A: 0, B: 1, C: 2, Min: -math.Pi / 6, Max: math.Pi / 6}
+
+	turn := func(p []Particle) float64 {
+		return RelativeAngleDiff(p[1].Pos.Subtract(p[0].Pos).Angle(), p[2].Pos.Subtract(p[1].Pos).Angle())
+	}
+
+	p := particles(a, b, c)
+	for range 20 {
+		angle.Project(p)
+	}
+
+	// The correction is linear in the turn, so a large error can end up
+	// inside the range rather than on its bound
+	if got := turn(p); got < angle.Min-1e-9 || got > angle.Max+1e-9 {
+		t.Errorf("turn is %v, want it within [%v, %v]", got, angle.Min, angle.Max)
+	}
+	if center, got := a.Add(b).Add(c), p[0].Pos.Add(p[1].Pos).Add(p[2].Pos); got.Distance(center) > 1e-9 {
+		t.Errorf("equal masses moved the center of mass from %v to %v", center, got)
+	}
+
+	p = particles(a, b, c)
+	p[0].InvMass = 0
+	angle.Project(p)
+	if p[0].Pos != a {
+		t.Errorf("immovable A moved to %v", p[0].Pos)
+	}
+	if got := turn(p); got >= math.Pi/2 {
+		t.Errorf("turn is still %v with A immovable", got)
+	}
+
+	// Within range nothing moves
+	p = particles(a, b, NewVector(20, 1))
+	angle.Project(p)
+	if p[2].Pos != NewVector(20, 1) {
+		t.Errorf("a turn in range moved C to %v", p[2].Pos)
+	}
+}
+
+func TestBendingProject(t *testing.T) {
+	p := particles(NewVector(0, 0), NewVector(10, 5), NewVector(20, 0))
+
+	rest := NewBending(p, 0, 1, 2, 1)
+	rest.Project(p)
+	if p[1].Pos != NewVector(10, 5) {
+		t.Errorf("a bending constraint at rest moved B to %v", p[1].Pos)
+	}
+
+	(&Bending{A: 0, B: 1, C: 2}).Project(p)
+	if got := p[1].Pos.Subtract(p[0].Pos).Cross(p[2].Pos.Subtract(p[1].Pos)); math.Abs(got) > 1e-9 {
+		t.Errorf("a Rest of 0 left the three bent, cross %v", got)
+	}
+}
+
+func TestPinProject(t *testing.T) {
+	p := particles(NewVector(0, 0))
+	p[0].InvMass = 0
+
+	(&Pin{P: 0, Pos: NewVector(5, 6)}).Project(p)
+	if p[0].Pos != NewVector(5, 6) {
+		t.Errorf("pinned particle at %v, want (5, 6)", p[0].Pos)
+	}
+}
+
+func TestCollisionProject(t *testing.T) {
+	circle := Circle{Center: NewVector(0, 0), Radius: 10}
+
+	p := particles(NewVector(5, 0))
+	(&Collision{P: 0, Radius: 2, Collider: circle}).Project(p)
+	if got := p[0].Pos.Distance(circle.Center); math.Abs(got-12) > 1e-9 {
+		t.Errorf("particle is %v from the center, want 12", got)
+	}
+
+	p[0] = Particle{Pos: NewVector(5, 0)}
+	(&Collision{P: 0, Radius: 2, Collider: circle}).Project(p)
+	if p[0].Pos != NewVector(5, 0) {
+		t.Errorf("an immovable particle was pushed to %v", p[0].Pos)
+	}
+}
+
+func TestAreaProject(t *testing.T) {
+	square := particles(NewVector(0, 0), NewVector(10, 0), NewVector(10, 10), NewVector(0, 10))
+	c := &Area{P: []int{0, 1, 2, 3}, Rest: 200}
+
+	c.Project(square)
+	area := 0.0
+	for i := range square {
+		area += square[i].Pos.Cross(square[(i+1)%4].Pos)
+	}
+	// The gradient step is linear, so one pass gets closer but not exact
+	if area /= 2; math.Abs(area-200) >= 100 {
+		t.Errorf("area is %v after a pass, want it closer to 200 than 100 is", area)
+	}
+
+	for range 20 {
+		c.Project(square)
+	}
+	area = 0
+	for i := range square {
+		area += square[i].Pos.Cross(square[(i+1)%4].Pos)
+	}
+	if area /= 2; math.Abs(area-200) > 1e-6 {
+		t.Errorf("area is %v, want 200", area)
+	}
+}
+
+func TestVerletChainKeepsSpecs(t *testing.T) {
+	c := NewChain(NewVector(400, 200), 12, 20, math.Pi/6)
+	v := NewVerletChain(c)
+	v.Iterations = 50
+
+	// Whip the head around so the bends hit their limits
+	for i := range 240 {
+		at := float64(i) / 10
+		v.Pin(0, NewVector(400+150*math.Cos(at), 200+150*math.Sin(at)))
+		v.Step(1.0 / 60)
+	}
+
+	joints, angles := v.Joints(), v.Angles()
+	for i := 1; i < len(joints); i++ {
+		if got := joints[i].Distance(joints[i-1]); math.Abs(got-20) > 0.5 {
+			t.Errorf("link %d is %v long, want 20", i, got)
+		}
+	}
+	for i := 2; i < len(angles); i++ {
+		if bend := angleDelta(angles[i-1], angles[i]); math.Abs(bend) > math.Pi/6+0.05 {
+			t.Errorf("joint %d bends %v, want at most %v", i, bend, math.Pi/6)
+		}
+	}
+
+	// Letting go keeps the speed of the last pin move
+	pinned := v.Velocity(0)
+	v.Unpin(0)
+	v.Gravity = Vector{}
+	v.Drag = 0
+	v.Step(1.0 / 60)
+	if got := v.Velocity(0); got.Distance(pinned) > pinned.Magnitude()/2 {
+		t.Errorf("head flies off at %v after Unpin, want about %v", got, pinned)
+	}
+}
+
+func TestRingKeepsArea(t *testing.T) {
+	r := NewRing(NewVector(0, 0), 16, 50)
+	rest := r.RestArea()
+
+	// Squeeze it from every side, pressure pushes it back out
+	r.Drag = 4
+	for range 300 {
+		for i := range r.Len() {
+			r.ApplyForce(i, r.Normal(i).Multiply(-200))
+		}
+		r.Step(1.0 / 60)
+	}
+
+	if got := math.Abs(r.Area()); math.Abs(got-rest) > rest*0.05 {
+		t.Errorf("area is %v, want about %v", got, rest)
+	}
+
+	loose := NewRing(NewVector(0, 0), 16, 50)
+	loose.Pressure = 0
+	for range 300 {
+		for i := range loose.Len() {
+			loose.ApplyForce(i, loose.Normal(i).Multiply(-200))
+		}
+		loose.Step(1.0 / 60)
+	}
+
+	if got := math.Abs(loose.Area()); got > rest*0.95 {
+		t.Errorf("a ring without pressure kept %v of its %v area", got, rest)
+	}
+}
+
+func TestRingMinimumJoints(t *testing.T) {
+	for _, n := range []int{-1, 0, 1, 2} {
+		r := NewRing(NewVector(0, 0), n, 50)
+		if r.Len() != 3 {
+			t.Errorf("NewRing with %d joints made %d, want 3", n, r.Len())
+			continue
+		}
+
+		for range 60 {
+			r.Step(1.0 / 60)
+		}
+		for i, p := range r.Joints() {
+			if next := r.Joints()[(i+1)%3]; math.IsNaN(p.X) || math.IsNaN(p.Y) || math.Abs(p.Distance(next)-50*math.Sqrt(3)) > 1 {
+				t.Errorf("NewRing with %d joints: joint %d at %v, %v from the next", n, i, p, p.Distance(next))
+			}
+		}
+	}
+}
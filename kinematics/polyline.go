@@ -0,0 +1,191 @@
+package kinematics
+
+import (
+	"math"
+	"slices"
+)
+
+// NewChainFromPoints creates a chain with one joint per spec laid along the
+// polyline points, head on the first point. Every joint sits where the
+// polyline first gets its spec length away from the joint before it, so the
+// links keep their exact length and the body follows the curve. Joints past
+// the end of the polyline carry on along its last segment. Bend ranges are
+// not checked until the first Resolve, so a curve tighter than the specs
+// allow straightens out then.
+func NewChainFromPoints(points []Vector, specs []JointSpec) *Chain {
+	var origin Vector
+	if len(points) > 0 {
+		origin = points[0]
+	}
+
+	c := NewChainFromSpecs(origin, specs)
+	c.layAlong(points)
+	c.resetMotion()
+
+	return c
+}
+
+// CatmullRom returns a polyline through every point with segments extra
+// points between each pair, following a smooth curve. Pass it to
+// NewChainFromPoints to fit a chain to a spline.
+func CatmullRom(points []Vector, segments int) []Vector {
+	if len(points) < 2 || segments < 1 {
+		return slices.Clone(points)
+	}
+
+	out := make([]Vector, 0, (len(points)-1)*(segments+1)+1)
+	for i := 0; i < len(points)-1; i++ {
+		// The ends are repeated so the curve reaches them
+		p0, p1, p2, p3 := points[max(i-1, 0)], points[i], points[i+1], points[min(i+2, len(points)-1)]
+
+		for s := 0; s <= segments; s++ {
+			t := float64(s) / float64(segments+1)
+			t2, t3 := t*t, t*t*t
+			out = append(out, p1.Multiply(2).
+				Add(p2.Subtract(p0).Multiply(t)).
+				Add(p0.Multiply(2).Subtract(p1.Multiply(5)).Add(p2.Multiply(4)).Subtract(p3).Multiply(t2)).
+				Add(p1.Multiply(3).Subtract(p0).Subtract(p2.Multiply(3)).Add(p3).Multiply(t3)).
+				Multiply(0.5))
+		}
+	}
+
+	return append(out, points[len(points)-1])
+}
+
+// Resample changes the chain to n joints, at least 1, along its current
+// curve. The head stays put and the body keeps its rest length, shared evenly
+// by the new links. Links longer than a bend of the curve cut across it. The
+// other spec fields are blended from the old joints at the same place along
+// the body. Growing links finish growing, springs stop swinging and every
+// joint starts at rest.
+func (c *Chain) Resample(n int) {
+	n = max(n, 1)
+
+	// Rest arc length from the head to each old joint
+	along := make([]float64, len(c.joints))
+	for i := 1; i < len(c.joints); i++ {
+		along[i] = along[i-1] + c.Spec(i).Length
+	}
+
+	// A chain with no links grows from the head template, like InsertJoint
+	link := c.specs[0].Length
+	if length := along[len(along)-1]; length > 0 && n > 1 {
+		link = length / float64(n-1)
+	}
+
+	specs := make([]JointSpec, n)
+	for j := range specs {
+		at := float64(j) * link
+		i := 0
+		for i+1 < len(along)-1 && along[i+1] <= at {
+			i++
+		}
+
+		spec := c.specs[i]
+		if i+1 < len(along) && along[i+1] > along[i] {
+			t := math.Min((at-along[i])/(along[i+1]-along[i]), 1)
+			spec = lerpSpec(c.specs[i], c.specs[i+1], t)
+		}
+
+		if n > 1 {
+			spec.Length = link
+		}
+		specs[j] = spec
+	}
+
+	points := c.joints
+	heading := c.angles[0]
+
+	c.joints = make([]Vector, n)
+	c.angles = make([]float64, n)
+	c.specs = specs
+	c.growth = make([]growth, n)
+	c.spin = make([]float64, n)
+
+	c.layAlong(points)
+	c.angles[0] = heading
+	c.resetMotion()
+}
+
+// layAlong places every joint along the polyline points as NewChainFromPoints
+// describes and measures the angles from the new pose
+func (c *Chain) layAlong(points []Vector) {
+	if len(points) == 0 {
+		points = []Vector{c.joints[0]}
+	}
+
+	c.joints[0] = points[0]
+	k, t := 0, 0.0 // Segment and fraction along it of the last joint placed
+
+	for i := 1; i < len(c.joints); i++ {
+		from := c.joints[i-1]
+		length := c.specs[i].Length
+
+		placed := false
+		for ; k < len(points)-1; k, t = k+1, 0 {
+			if s, ok := crossing(from, length, points[k], points[k+1], t); ok {
+				c.joints[i] = points[k].Lerp(points[k+1], s)
+				t = s
+				placed = true
+				break
+			}
+		}
+
+		if !placed {
+			// Past the end, carry on along the last segment
+			dir := NewVector(0, 1)
+			if last := len(points) - 1; last > 0 && points[last] != points[last-1] {
+				dir = points[last].Subtract(points[last-1])
+			}
+			c.joints[i] = from.Add(dir.SetMag(length))
+		}
+	}
+
+	c.measureAngles()
+	if len(c.angles) > 1 {
+		c.angles[0] = c.angles[1]
+	}
+}
+
+// crossing returns the first fraction from start on along the segment a to b
+// where it is radius away from center
+func crossing(center Vector, radius float64, a, b Vector, start float64) (float64, bool) {
+	d := b.Subtract(a)
+	f := a.Subtract(center)
+
+	dd := d.Dot(d)
+	if dd == 0 {
+		return 0, false
+	}
+
+	// |f + d s|^2 = radius^2
+	half := f.Dot(d) / dd
+	disc := half*half - (f.Dot(f)-radius*radius)/dd
+	if disc < 0 {
+		return 0, false
+	}
+
+	root := math.Sqrt(disc)
+	for _, s := range [2]float64{-half - root, -half + root} {
+		if s >= start && s <= 1 {
+			return s, true
+		}
+	}
+
+	return 0, false
+}
+
+// lerpSpec blends every field of two specs, t = 0 gives a
+func lerpSpec(a, b JointSpec, t float64) JointSpec {
+	lerp := func(x, y float64) float64 { return x + (y-x)*t }
+
+	return JointSpec{
+		Length:    lerp(a.Length, b.Length),
+		Min:       lerp(a.Min, b.Min),
+		Max:       lerp(a.Max, b.Max),
+		Radius:    lerp(a.Radius, b.Radius),
+		Rest:      lerp(a.Rest, b.Rest),
+		Stiffness: lerp(a.Stiffness, b.Stiffness),
+		Damping:   lerp(a.Damping, b.Damping),
+	}
+}
@@ -0,0 +1,133 @@
+package kinematics
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// offPolyline returns how far p is from the nearest segment of points
+func offPolyline(points []Vector, p Vector) float64 {
+	off := math.Inf(1)
+	for k := 1; k < len(points); k++ {
+		s := Segment{A: points[k-1], B: points[k]}
+		off = math.Min(off, s.Closest(p).Distance(p))
+	}
+
+	return off
+}
+
+// wave returns a smooth curve through a few points of a sine
+func wave() []Vector {
+	var points []Vector
+	for k := range 8 {
+		points = append(points, NewVector(float64(k)*40, 50*math.Sin(float64(k))))
+	}
+
+	return CatmullRom(points, 8)
+}
+
+func TestNewChainFromPoints(t *testing.T) {
+	points := []Vector{NewVector(0, 0), NewVector(100, 0), NewVector(100, 80), NewVector(20, 120)}
+	specs := make([]JointSpec, 12)
+	for i := range specs {
+		specs[i] = JointSpec{Length: 7 + float64(i%4)*5, Min: -1, Max: 1}
+	}
+
+	c := NewChainFromPoints(points, specs)
+	if c.Len() != len(specs) || c.Head() != points[0] {
+		t.Fatalf("got %d joints, head at %v; want %d, head at %v", c.Len(), c.Head(), len(specs), points[0])
+	}
+
+	joints := c.Joints()
+	for i := 1; i < len(joints); i++ {
+		if got := joints[i].Distance(joints[i-1]); math.Abs(got-specs[i].Length) > 1e-9 {
+			t.Errorf("link %d is %v long, want %v", i, got, specs[i].Length)
+		}
+		if off := offPolyline(points, joints[i]); off > 1e-9 {
+			t.Errorf("joint %d at %v is %v off the polyline", i, joints[i], off)
+		}
+	}
+
+	// Past the end the joints carry on along the last segment
+	short := NewChainFromPoints(points[:2], specs)
+	for i, p := range short.Joints() {
+		if p.Y != 0 || i > 0 && p.X <= short.Joints()[i-1].X {
+			t.Errorf("joint %d at %v is not along the line", i, p)
+		}
+	}
+}
+
+func TestCatmullRom(t *testing.T) {
+	points := []Vector{NewVector(0, 0), NewVector(50, 20), NewVector(80, -30), NewVector(120, 0)}
+
+	curve := CatmullRom(points, 5)
+	if want := (len(points)-1)*6 + 1; len(curve) != want {
+		t.Fatalf("got %d points, want %d", len(curve), want)
+	}
+	for k, p := range points {
+		if got := curve[k*6]; got.Distance(p) > 1e-9 {
+			t.Errorf("curve passes %v where it should pass control point %v", got, p)
+		}
+	}
+
+	for _, tt := range []struct {
+		points   []Vector
+		segments int
+	}{{points[:1], 5}, {nil, 5}, {points, 0}} {
+		if got := CatmullRom(tt.points, tt.segments); !slices.Equal(got, tt.points) {
+			t.Errorf("CatmullRom(%v, %d) = %v, want the points back", tt.points, tt.segments, got)
+		}
+	}
+}
+
+func TestResample(t *testing.T) {
+	for _, n := range []int{40, 12, 5, 2} {
+		specs := make([]JointSpec, 20)
+		for i := range specs {
+			specs[i] = JointSpec{Length: 8 + float64(i%3)*4, Min: -2, Max: 2}
+		}
+
+		c := NewChainFromPoints(wave(), specs)
+		head, heading, length := c.Head(), c.Angles()[0], c.Length()
+
+		before := slices.Clone(c.Joints())
+		tail := before[len(before)-1]
+		onward := tail.Subtract(before[len(before)-2]).Normalize()
+
+		c.Resample(n)
+		if c.Len() != n || c.Head() != head || c.Angles()[0] != heading {
+			t.Fatalf("resampled to %d joints, head %v facing %v; want %d, %v, %v",
+				c.Len(), c.Head(), c.Angles()[0], n, head, heading)
+		}
+		if got := c.Length(); math.Abs(got-length) > 1e-9 {
+			t.Errorf("%d joints: body is %v long, want %v", n, got, length)
+		}
+
+		// Every link gets the same share, and the joints stay on the old body.
+		// Links cut across the bends, so the last joints may run on past the
+		// old tail, carrying on the way it pointed.
+		joints := c.Joints()
+		link := length / float64(n-1)
+		for i := 1; i < len(joints); i++ {
+			if got := joints[i].Distance(joints[i-1]); math.Abs(got-link) > 1e-9 {
+				t.Errorf("%d joints: link %d is %v long, want %v", n, i, got, link)
+			}
+
+			if joints[i-1].Distance(tail) < link {
+				if got := joints[i].Subtract(joints[i-1]).Normalize(); got.Distance(onward) > 1e-9 {
+					t.Errorf("%d joints: joint %d runs on toward %v, want %v", n, i, got, onward)
+				}
+			} else if off := offPolyline(before, joints[i]); off > 1e-9 {
+				t.Errorf("%d joints: joint %d is %v off the old body", n, i, off)
+			}
+		}
+	}
+
+	c := NewChainFromPoints(wave(), []JointSpec{{Length: 5}, {Length: 5}, {Length: 5}})
+	head := c.Head()
+	c.Resample(0)
+	if c.Len() != 1 || c.Head() != head {
+		t.Errorf("resampled to %d joints with head %v, want a lone head at %v", c.Len(), c.Head(), head)
+	}
+}
@@ -0,0 +1,161 @@
+package kinematics
+
+import (
+	"math"
+	"slices"
+)
+
+// Ring is a closed loop of joints, the last one linked back to the first. It
+// is a System with a Distance constraint on every link around the loop. With
+// Pressure set, an Area constraint also pushes its outline in or out to keep
+// the area it encloses, so it wobbles like a blob or cell instead of folding
+// up like a loose loop of string.
+type Ring struct {
+	system *System
+	joints []Vector    // Particle positions after the last step
+	links  []*Distance // One per joint, to the next joint around the loop
+	area   *Area       // Rest area, signed like Area
+
+	Gravity    Vector  // Acceleration applied to every joint
+	Drag       float64 // Rate velocity decays at, it keeps exp(-Drag * dt) of itself each step
+	Iterations int     // Constraint relaxation passes per step, 0 = DefaultIterations
+	Pressure   float64 // Share of the area error corrected per pass, 0 = a loose loop, 1 = incompressible
+
+	lastDt float64
+}
+
+// NewRing creates a ring of jointCount joints at rest on a circle around
+// center. A ring needs three joints to enclose anything, so a jointCount
+// below 3 gives three.
+func NewRing(center Vector, jointCount int, radius float64) *Ring {
+	jointCount = max(jointCount, 3)
+
+	r := &Ring{
+		system:   NewSystem(),
+		area:     &Area{},
+		Gravity:  NewVector(0, DefaultGravity),
+		Drag:     0.5,
+		Pressure: 1,
+	}
+
+	for i := 0; i < jointCount; i++ {
+		p := center.Add(FromAngle(TwoPi * float64(i) / float64(jointCount)).Multiply(radius))
+		r.area.P = append(r.area.P, r.system.AddParticle(p, 1))
+	}
+
+	for i := 0; i < jointCount; i++ {
+		link := &Distance{A: i, B: (i + 1) % jointCount}
+		r.links = append(r.links, link)
+		r.system.Add(link)
+	}
+
+	r.joints = r.system.Positions(nil)
+	r.SetRadius(radius)
+
+	return r
+}
+
+// Joints returns the joint positions around the loop. The slice must not be modified.
+func (r *Ring) Joints() []Vector {
+	return r.joints
+}
+
+// Len returns the number of joints
+func (r *Ring) Len() int {
+	return len(r.joints)
+}
+
+// Center returns the average of the joints
+func (r *Ring) Center() Vector {
+	var sum Vector
+	for _, p := range r.joints {
+		sum = sum.Add(p)
+	}
+
+	return sum.Divide(float64(len(r.joints)))
+}
+
+// Area returns the area enclosed by the joints. It is negative when the joints
+// run the other way around the loop.
+func (r *Ring) Area() float64 {
+	area := 0.0
+	for i, p := range r.joints {
+		area += p.Cross(r.joints[(i+1)%len(r.joints)])
+	}
+
+	return area / 2
+}
+
+// SetArea changes the area the pressure keeps, deflating or inflating the ring
+// on the next steps without changing its link lengths
+func (r *Ring) SetArea(area float64) {
+	r.area.Rest = math.Copysign(area, r.Area())
+}
+
+// RestArea returns the area the pressure keeps
+func (r *Ring) RestArea() float64 {
+	return math.Abs(r.area.Rest)
+}
+
+// SetRadius resizes the ring at rest to a circle of the given radius, changing
+// both its link lengths and its area. Pulsing the radius makes a jelly swim.
+func (r *Ring) SetRadius(radius float64) {
+	n := float64(len(r.joints))
+	length := 2 * radius * math.Sin(math.Pi/n)
+	for _, link := range r.links {
+		link.Min, link.Max = length, length
+	}
+
+	r.SetArea(n / 2 * radius * radius * math.Sin(TwoPi/n))
+}
+
+// Velocity returns the velocity of joint i over the last step, in pixels per second
+func (r *Ring) Velocity(i int) Vector {
+	if r.lastDt == 0 {
+		return Vector{}
+	}
+
+	p := r.system.particles[i]
+	return p.Pos.Subtract(p.Prev).Divide(r.lastDt)
+}
+
+// ApplyForce adds an acceleration to joint i for the next step
+func (r *Ring) ApplyForce(i int, accel Vector) {
+	p := &r.system.particles[i]
+	p.Force = p.Force.Add(accel)
+}
+
+// Normal returns the outward direction of the outline at joint i
+func (r *Ring) Normal(i int) Vector {
+	n := len(r.joints)
+	along := r.joints[(i+1)%n].Subtract(r.joints[(i+n-1)%n])
+	normal := Vector{X: along.Y, Y: -along.X}
+	if r.area.Rest < 0 {
+		normal = normal.Multiply(-1)
+	}
+
+	return normal.Normalize()
+}
+
+// Step integrates dt seconds and then relaxes the links and the pressure
+func (r *Ring) Step(dt float64) {
+	r.system.Gravity = r.Gravity
+	r.system.Drag = r.Drag
+	r.system.Iterations = r.Iterations
+
+	// The area constraint goes after the links, and only while there is pressure
+	pressurized := slices.Contains(r.system.constraints, Constraint(r.area))
+	if r.Pressure > 0 && !pressurized {
+		r.system.Add(r.area)
+	} else if r.Pressure <= 0 && pressurized {
+		r.system.Remove(r.area)
+	}
+	r.area.Stiffness = r.Pressure
+
+	if dt > 0 {
+		r.lastDt = dt
+	}
+
+	r.system.Step(dt)
+	r.joints = r.system.Positions(r.joints[:0])
+}
@@ -0,0 +1,112 @@
+package kinematics
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// selfCollision keeps joints of the same chain from passing through each
+// other. The scratch buffers are kept between calls.
+type selfCollision struct {
+	gap   float64   // Extra space kept between the bodies of two joints
+	along []float64 // Distance from the head to each joint along the rest pose
+	order []int     // Joint indices sorted by x, the broad phase
+	pairs [][2]int  // Candidate pairs found by the broad phase
+}
+
+// SetSelfCollision keeps every pair of joints at least their two radii plus
+// gap apart. Joints close enough along the chain to overlap even when it is
+// straight are never pushed apart, so tight bodies do not fight themselves.
+func (c *Chain) SetSelfCollision(gap float64) {
+	if c.self == nil {
+		c.self = &selfCollision{}
+	}
+
+	c.self.gap = gap
+}
+
+// DisableSelfCollision turns the self collision pass off
+func (c *Chain) DisableSelfCollision() {
+	c.self = nil
+}
+
+// SelfCollision reports whether the self collision pass is on, and its gap
+func (c *Chain) SelfCollision() (bool, float64) {
+	if c.self == nil {
+		return false, 0
+	}
+
+	return true, c.self.gap
+}
+
+// separate pushes overlapping joints apart and reports whether any moved
+func (c *Chain) separate() bool {
+	s := c.self
+	n := len(c.joints)
+
+	s.along = s.along[:0]
+	maxRadius := 0.0
+	distance := 0.0
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			distance += c.specs[i].Length
+		}
+		s.along = append(s.along, distance)
+		maxRadius = math.Max(maxRadius, c.specs[i].Radius)
+	}
+
+	reach := 2*maxRadius + s.gap
+	if reach <= 0 {
+		return false
+	}
+
+	s.broadPhase(c.joints, reach)
+
+	moved := false
+	for _, pair := range s.pairs {
+		i, j := pair[0], pair[1]
+		clearance := c.specs[i].Radius + c.specs[j].Radius + s.gap
+
+		// Neighbours along the body overlap by design
+		if s.along[j]-s.along[i] <= clearance {
+			continue
+		}
+
+		if c.joints[i].DistanceSquared(c.joints[j]) >= clearance*clearance {
+			continue
+		}
+
+		c.joints[i], c.joints[j] = ConstrainDistanceSymmetric(c.joints[i], c.joints[j], clearance, math.Inf(1))
+		moved = true
+	}
+
+	return moved
+}
+
+// broadPhase sweeps the joints sorted by x and collects every pair closer
+// than reach on both axes, lower index first. Ties sort by index so the pairs
+// always come out in the same order.
+func (s *selfCollision) broadPhase(joints []Vector, reach float64) {
+	s.order = s.order[:0]
+	for i := range joints {
+		s.order = append(s.order, i)
+	}
+
+	slices.SortFunc(s.order, func(a, b int) int {
+		return cmp.Or(cmp.Compare(joints[a].X, joints[b].X), cmp.Compare(a, b))
+	})
+
+	s.pairs = s.pairs[:0]
+	for k, i := range s.order {
+		for _, j := range s.order[k+1:] {
+			if joints[j].X-joints[i].X >= reach {
+				break
+			}
+
+			if math.Abs(joints[j].Y-joints[i].Y) < reach {
+				s.pairs = append(s.pairs, [2]int{min(i, j), max(i, j)})
+			}
+		}
+	}
+}
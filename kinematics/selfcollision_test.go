@@ -0,0 +1,100 @@
+package kinematics
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// roundChain returns a chain of n joints with links of the given length and
+// joints of the given radius, free to bend nearly all the way back
+func roundChain(n int, link, radius float64) *Chain {
+	specs := make([]JointSpec, n)
+	for i := range specs {
+		specs[i] = JointSpec{Length: link, Min: -3, Max: 3, Radius: radius}
+	}
+
+	return NewChainFromSpecs(NewVector(0, 0), specs)
+}
+
+func TestSelfCollisionCoil(t *testing.T) {
+	const gap = 2
+	c := roundChain(30, 10, 6)
+	c.SetSelfCollision(gap)
+
+	// Wind the head around a shrinking spiral so the body coils onto itself
+	for step := 1; step <= 600; step++ {
+		at := float64(step) / 20
+		radius := math.Max(10, 150-float64(step)/4)
+		c.Resolve(NewVector(radius*math.Cos(at), radius*math.Sin(at)), 1.0/60)
+	}
+
+	joints := c.Joints()
+	for i := range joints {
+		for j := i + 1; j < len(joints); j++ {
+			clearance := c.Spec(i).Radius + c.Spec(j).Radius + gap
+			if float64(j-i)*10 <= clearance {
+				continue
+			}
+
+			// The pass is iterative, so joints may be left a sliver inside
+			if got := joints[i].Distance(joints[j]); got < clearance-0.1 {
+				t.Errorf("joints %d and %d are %v apart, want at least %v", i, j, got, clearance)
+			}
+		}
+	}
+}
+
+func TestSelfCollisionSkipsNeighbours(t *testing.T) {
+	// Radii 6 and no gap: joints up to 12 apart along the body are neighbours
+	c := roundChain(4, 5, 6)
+	c.SetSelfCollision(0)
+
+	// Fold the body so joint 2 lies on joint 0 and joint 3 next to it. Only
+	// joints 0 and 3 are far enough apart along the body to be pushed.
+	pose := []Vector{NewVector(0, 0), NewVector(5, 0), NewVector(0, 0), NewVector(0, 1)}
+	copy(c.joints, pose)
+
+	c.separate()
+	for _, i := range []int{1, 2} {
+		if c.joints[i] != pose[i] {
+			t.Errorf("joint %d, a neighbour of joint 0, moved to %v", i, c.joints[i])
+		}
+	}
+	if got := c.joints[0].Distance(c.joints[3]); math.Abs(got-12) > 1e-9 {
+		t.Errorf("joints 0 and 3 are %v apart, want 12", got)
+	}
+}
+
+func TestBroadPhase(t *testing.T) {
+	joints := []Vector{
+		NewVector(0, 0), NewVector(5, 5), NewVector(5, -20), NewVector(12, 0),
+		NewVector(3, 2), NewVector(5, 5), NewVector(-8, 1),
+	}
+	const reach = 10
+
+	var want [][2]int
+	for i := range joints {
+		for j := i + 1; j < len(joints); j++ {
+			if math.Abs(joints[i].X-joints[j].X) < reach && math.Abs(joints[i].Y-joints[j].Y) < reach {
+				want = append(want, [2]int{i, j})
+			}
+		}
+	}
+
+	var s selfCollision
+	s.broadPhase(joints, reach)
+
+	got := slices.Clone(s.pairs)
+	slices.SortFunc(got, func(a, b [2]int) int { return a[0]*len(joints) + a[1] - b[0]*len(joints) - b[1] })
+	if !slices.Equal(got, want) {
+		t.Errorf("broad phase found %v, want %v", got, want)
+	}
+
+	// The same joints always give the same pairs in the same order
+	first := slices.Clone(s.pairs)
+	s.broadPhase(joints, reach)
+	if !slices.Equal(s.pairs, first) {
+		t.Errorf("second sweep gave %v, first %v", s.pairs, first)
+	}
+}
@@ -0,0 +1,221 @@
+package kinematics
+
+import "math"
+
+const (
+	// DefaultGrip is how much harder the ground resists a body sliding sideways
+	// than along itself, the ratio that turns a body wave into thrust
+	DefaultGrip = 10.0
+)
+
+// Serpenoid is a solver that swims the chain instead of dragging it. A wave
+// of bends travels from the head to the tail, and the ground resists each
+// joint sliding sideways much more than along the body. The body moves however
+// balances those friction forces, so how fast it goes comes from the shape of
+// the wave rather than from the target. The target only steers: every joint
+// bends a little extra toward it. Bends stay within the spec ranges.
+//
+// Steering is measured against the heading of the head smoothed over a wave,
+// so the wave itself does not swing it, and the added bend changes by at most
+// Turn per wave.
+//
+// Serpenoid keeps the wave phase and steering, so each chain needs its own
+// pointer. Copy the struct to start another chain on the same wave.
+type Serpenoid struct {
+	Amplitude  float64 // Peak bend of each joint, in radians
+	Frequency  float64 // Waves per second
+	Wavelength float64 // Joints per wave
+	Turn       float64 // Most bend added to every joint to steer toward the target
+	Grip       float64 // Sideways over lengthwise friction, 0 = DefaultGrip
+
+	phase  float64
+	yaw    float64 // Smoothed heading of the head
+	steer  float64 // Bend added to every joint this frame
+	steady bool    // Whether yaw has been measured yet
+}
+
+// Phase returns where the wave is at the head, in radians
+func (s *Serpenoid) Phase() float64 {
+	return s.phase
+}
+
+// SetPhase moves the wave to phase radians at the head
+func (s *Serpenoid) SetPhase(phase float64) {
+	s.phase = math.Mod(phase, TwoPi)
+}
+
+// split returns a copy of the wave for the joints from index on of a chain of
+// n joints, so the tail cut off there carries on with the bends it had. The
+// copy measures its own heading afresh.
+func (s *Serpenoid) split(index, n int) *Serpenoid {
+	wavelength := s.Wavelength
+	if wavelength <= 0 {
+		wavelength = float64(n)
+	}
+
+	tail := *s
+	tail.SetPhase(s.phase - TwoPi*float64(index)/wavelength)
+	tail.steady = false
+
+	return &tail
+}
+
+// Solve implements Solver
+func (s *Serpenoid) Solve(c *Chain, target Vector, dt float64) Result {
+	if len(c.joints) > 1 && dt > 0 {
+		s.phase = math.Mod(s.phase+TwoPi*s.Frequency*dt, TwoPi)
+		s.undulate(c, target, dt)
+	}
+
+	return Result{Iterations: 1, Error: c.joints[0].Distance(target)}
+}
+
+// undulate bends the chain to the wave at the current phase and moves it by
+// the rigid motion that leaves no net friction force or torque on the body
+func (s *Serpenoid) undulate(c *Chain, target Vector, dt float64) {
+	n := len(c.joints)
+
+	// Steer by curving the whole body toward the target
+	bias := s.steering(c, target, dt)
+
+	wavelength := s.Wavelength
+	if wavelength <= 0 {
+		wavelength = float64(n)
+	}
+
+	// Lay the new shape out head first, then fit it over the old pose
+	shape := make([]Vector, 1, n)
+	angle := 0.0
+	for i := 1; i < n; i++ {
+		bend := s.Amplitude*math.Sin(s.phase-TwoPi*float64(i-1)/wavelength) + bias
+		angle += math.Max(c.specs[i].Min, math.Min(c.specs[i].Max, bend))
+		shape = append(shape, shape[i-1].Subtract(FromAngle(angle).Multiply(c.specs[i].Length)))
+	}
+
+	center := fitPose(shape, c.joints)
+
+	grip := s.Grip
+	if grip <= 0 {
+		grip = DefaultGrip
+	}
+
+	// Friction is lengthwise drag plus grip times the sideways drag. Solve for
+	// the velocity and spin about center that cancel the friction of the shape
+	// change, M [vx vy spin] = rhs.
+	var m [3][3]float64
+	var rhs [3]float64
+	for i := range shape {
+		tangent := tangent(shape, i)
+		r := shape[i].Subtract(center)
+		u := shape[i].Subtract(c.joints[i]).Divide(dt)
+
+		drag := func(v Vector) Vector {
+			along := tangent.Multiply(v.Dot(tangent))
+			return along.Add(v.Subtract(along).Multiply(grip))
+		}
+
+		dx, dy, dspin := drag(Vector{X: 1}), drag(Vector{Y: 1}), drag(Vector{X: -r.Y, Y: r.X})
+		du := drag(u)
+
+		m[0][0], m[0][1], m[0][2] = m[0][0]+dx.X, m[0][1]+dy.X, m[0][2]+dspin.X
+		m[1][0], m[1][1], m[1][2] = m[1][0]+dx.Y, m[1][1]+dy.Y, m[1][2]+dspin.Y
+		m[2][0], m[2][1], m[2][2] = m[2][0]+r.Cross(dx), m[2][1]+r.Cross(dy), m[2][2]+r.Cross(dspin)
+		rhs[0], rhs[1], rhs[2] = rhs[0]-du.X, rhs[1]-du.Y, rhs[2]-r.Cross(du)
+	}
+
+	v, ok := solve3(m, rhs)
+	if !ok {
+		v = [3]float64{}
+	}
+
+	move := Vector{X: v[0], Y: v[1]}.Multiply(dt)
+	for i := range shape {
+		c.joints[i] = center.Add(move).Add(shape[i].Subtract(center).Rotate(v[2] * dt))
+	}
+
+	c.measureAngles()
+	c.angles[0] = c.angles[1]
+}
+
+// steering returns the bend to add to every joint to turn toward target. The
+// heading of the head swings with the wave, so it is smoothed over a wave
+// before it is compared with the target, and the bend eases toward the one
+// wanted rather than following it frame by frame.
+func (s *Serpenoid) steering(c *Chain, target Vector, dt float64) float64 {
+	heading := c.joints[0].Subtract(c.joints[1]).Angle()
+	if !s.steady {
+		s.yaw, s.steer, s.steady = heading, 0, true
+	}
+
+	blend := math.Min(1, s.Frequency*dt)
+	s.yaw = SimplifyAngle(s.yaw + angleDelta(s.yaw, heading)*blend)
+
+	off := angleDelta(s.yaw, target.Subtract(c.joints[0]).Angle())
+	want := -s.Turn * math.Max(-1, math.Min(1, off/(math.Pi/2)))
+
+	step := math.Abs(s.Turn) * blend
+	s.steer += math.Max(-step, math.Min(step, want-s.steer))
+
+	return s.steer
+}
+
+// tangent returns the direction along shape at joint i, toward the head
+func tangent(shape []Vector, i int) Vector {
+	if i == 0 {
+		i = 1
+	}
+
+	return shape[i-1].Subtract(shape[i]).Normalize()
+}
+
+// fitPose moves shape onto pose by the rotation and translation that best
+// match the two, so the change between them has no rigid part. It returns the
+// shared center.
+func fitPose(shape, pose []Vector) Vector {
+	var shapeCenter, poseCenter Vector
+	for i := range shape {
+		shapeCenter = shapeCenter.Add(shape[i])
+		poseCenter = poseCenter.Add(pose[i])
+	}
+	shapeCenter = shapeCenter.Divide(float64(len(shape)))
+	poseCenter = poseCenter.Divide(float64(len(pose)))
+
+	var cross, dot float64
+	for i := range shape {
+		a, b := shape[i].Subtract(shapeCenter), pose[i].Subtract(poseCenter)
+		cross += a.Cross(b)
+		dot += a.Dot(b)
+	}
+
+	turn := math.Atan2(cross, dot)
+	for i := range shape {
+		shape[i] = poseCenter.Add(shape[i].Subtract(shapeCenter).Rotate(turn))
+	}
+
+	return poseCenter
+}
+
+// solve3 solves m x = rhs by Cramer's rule, reporting false when m is singular
+func solve3(m [3][3]float64, rhs [3]float64) ([3]float64, bool) {
+	det := func(a [3][3]float64) float64 {
+		return a[0][0]*(a[1][1]*a[2][2]-a[1][2]*a[2][1]) -
+			a[0][1]*(a[1][0]*a[2][2]-a[1][2]*a[2][0]) +
+			a[0][2]*(a[1][0]*a[2][1]-a[1][1]*a[2][0])
+	}
+
+	d := det(m)
+	if math.Abs(d) < 1e-12 {
+		return [3]float64{}, false
+	}
+
+	var x [3]float64
+	for col := 0; col < 3; col++ {
+		a := m
+		for row := 0; row < 3; row++ {
+			a[row][col] = rhs[row]
+		}
+		x[col] = det(a) / d
+	}
+
+	return x, true
+}
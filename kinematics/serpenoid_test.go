@@ -0,0 +1,36 @@
+package kinematics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSerpenoidSwimsToTarget(t *testing.T) {
+	for _, turn := range []float64{0, 0.15} {
+		c := NewChain(NewVector(0, 0), 16, 20, math.Pi/6)
+		c.SetSolver(&Serpenoid{Amplitude: 0.3, Frequency: 1, Wavelength: 12, Turn: turn})
+
+		start := c.Head()
+		ahead := start.Subtract(c.Joints()[1]).Normalize()
+		target := start.Add(ahead.Multiply(5000))
+
+		// The first call bends the straight chain into the wave
+		c.Resolve(target, 1.0/60)
+
+		prev := append([]Vector(nil), c.Joints()...)
+		for frame := range 600 {
+			c.Resolve(target, 1.0/60)
+			for i, p := range c.Joints() {
+				if got := p.Distance(prev[i]); got > 8 {
+					t.Fatalf("turn %v: joint %d moved %v in frame %d", turn, i, got, frame)
+				}
+			}
+			prev = append(prev[:0], c.Joints()...)
+		}
+
+		moved := c.Head().Subtract(start)
+		if forward, aside := moved.Dot(ahead), math.Abs(moved.Cross(ahead)); forward < 1000 || aside > forward/10 {
+			t.Errorf("turn %v: head went %v forward and %v aside, want it to swim ahead", turn, forward, aside)
+		}
+	}
+}
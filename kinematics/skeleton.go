@@ -0,0 +1,147 @@
+package kinematics
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrUnknownParent is returned by Skeleton.Attach for a parent that is not in
+// the skeleton
+var ErrUnknownParent = errors.New("kinematics: skeleton parent must be the spine or an attached chain")
+
+// ErrNoJoint is returned by Skeleton.Attach for a joint index outside the parent
+var ErrNoJoint = errors.New("kinematics: skeleton joint is not on the parent chain")
+
+// Limb hangs a child chain off a joint of a parent chain, like a leg on a
+// spine, a fin on a fish or a tentacle on a body
+type Limb struct {
+	Chain    *Chain  // The child, its head sits on the root
+	Parent   *Chain  // The chain the child hangs from
+	Joint    int     // Index of the parent joint the child hangs from
+	Offset   float64 // Direction the child points, relative to the parent heading at Joint (0 = forward, pi = back)
+	Distance float64 // How far the root sits from the parent joint, in the Offset direction
+
+	// A reaching limb is planted on Target instead of hanging, like a leg on
+	// the ground: its tail sits on the root and its head reaches for Target
+	// with TwoBone
+	Reaching bool
+	Target   Vector
+}
+
+// Skeleton is a tree of chains grown from a single spine. Limbs are resolved
+// after the spine, parents before children, so every child starts from the
+// pose its parent has this frame.
+type Skeleton struct {
+	spine *Chain
+	limbs []*Limb
+}
+
+// NewSkeleton creates a skeleton with nothing attached to spine yet
+func NewSkeleton(spine *Chain) *Skeleton {
+	return &Skeleton{spine: spine}
+}
+
+// Spine returns the root chain
+func (s *Skeleton) Spine() *Chain {
+	return s.spine
+}
+
+// Limbs returns the limbs in resolve order. The slice must not be modified.
+func (s *Skeleton) Limbs() []*Limb {
+	return s.limbs
+}
+
+// Attach hangs child off joint of parent, pointing in the offset direction
+// relative to the parent heading there. The parent must be the spine or a
+// chain attached earlier, which keeps the resolve order parent to child;
+// any other parent attaches nothing and returns ErrUnknownParent. A joint
+// the parent does not have returns ErrNoJoint.
+func (s *Skeleton) Attach(parent *Chain, joint int, offset float64, child *Chain) (*Limb, error) {
+	if !s.contains(parent) {
+		return nil, ErrUnknownParent
+	}
+
+	if joint < 0 || joint >= parent.Len() {
+		return nil, ErrNoJoint
+	}
+
+	limb := &Limb{Chain: child, Parent: parent, Joint: joint, Offset: offset}
+	s.limbs = append(s.limbs, limb)
+
+	limb.resolve(0)
+
+	return limb, nil
+}
+
+// Detach removes the limb holding child, along with every limb attached to it
+func (s *Skeleton) Detach(child *Chain) {
+	removed := map[*Chain]bool{child: true}
+
+	// Children always come after their parents, so one pass catches the subtree
+	kept := s.limbs[:0]
+	for _, limb := range s.limbs {
+		if removed[limb.Chain] || removed[limb.Parent] {
+			removed[limb.Chain] = true
+			continue
+		}
+
+		kept = append(kept, limb)
+	}
+
+	s.limbs = kept
+}
+
+// Resolve moves the spine toward pos with its solver, then places every limb
+// on its parent
+func (s *Skeleton) Resolve(pos Vector, dt float64) Result {
+	result := s.spine.Resolve(pos, dt)
+	s.ResolveLimbs(dt)
+
+	return result
+}
+
+// ResolveLimbs places every limb on its parent without moving the spine, for
+// creatures that pick limb targets from the spine pose of this frame
+func (s *Skeleton) ResolveLimbs(dt float64) {
+	for _, limb := range s.limbs {
+		limb.resolve(dt)
+	}
+}
+
+// Root returns where the limb is rooted and the direction it points
+func (l *Limb) Root() (Vector, float64) {
+	joint := l.Joint
+	if joint >= len(l.Parent.joints) {
+		// The parent lost joints, hang from its tail instead
+		joint = len(l.Parent.joints) - 1
+	}
+
+	direction := l.Parent.angles[joint] + l.Offset
+	return l.Parent.joints[joint].Add(FromAngle(direction).Multiply(l.Distance)), direction
+}
+
+func (l *Limb) resolve(dt float64) {
+	pos, direction := l.Root()
+
+	if l.Reaching {
+		l.Chain.TwoBoneResolve(l.Target, pos)
+		return
+	}
+
+	// Chain headings point back toward the head, the opposite of the way the limb extends
+	l.Chain.Attach(pos, direction+math.Pi, dt)
+}
+
+func (s *Skeleton) contains(c *Chain) bool {
+	if c == s.spine {
+		return true
+	}
+
+	for _, limb := range s.limbs {
+		if limb.Chain == c {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+package kinematics
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSkeletonAttach(t *testing.T) {
+	spine := NewChain(NewVector(0, 0), 5, 20, math.Pi/8)
+	s := NewSkeleton(spine)
+
+	leg := NewChain(NewVector(0, 0), 3, 10, math.Pi/4)
+	if _, err := s.Attach(spine, 2, math.Pi/2, leg); err != nil {
+		t.Fatalf("attaching to the spine: %v", err)
+	}
+
+	if got, want := leg.Head(), spine.Joints()[2]; got.Distance(want) > 1e-9 {
+		t.Errorf("leg head at %v, want it on spine joint 2 at %v", got, want)
+	}
+
+	stray := NewChain(NewVector(0, 0), 3, 10, math.Pi/4)
+	if _, err := s.Attach(stray, 1, 0, NewChain(NewVector(0, 0), 2, 5, 1)); !errors.Is(err, ErrUnknownParent) {
+		t.Errorf("attaching to a stray chain gave %v, want ErrUnknownParent", err)
+	}
+
+	for _, joint := range []int{-1, spine.Len()} {
+		if _, err := s.Attach(spine, joint, 0, NewChain(NewVector(0, 0), 2, 5, 1)); !errors.Is(err, ErrNoJoint) {
+			t.Errorf("attaching to joint %d of %d gave %v, want ErrNoJoint", joint, spine.Len(), err)
+		}
+	}
+
+	if len(s.Limbs()) != 1 {
+		t.Errorf("got %d limbs, want 1", len(s.Limbs()))
+	}
+}
+
+func TestSkeletonReachingLimb(t *testing.T) {
+	spine := NewChain(NewVector(0, 0), 5, 20, math.Pi/8)
+	s := NewSkeleton(spine)
+
+	leg, err := s.Attach(spine, 2, math.Pi/2, NewChain(NewVector(0, 0), 3, 10, math.Pi))
+	if err != nil {
+		t.Fatalf("attaching to the spine: %v", err)
+	}
+	leg.Distance = 5
+	leg.Reaching = true
+
+	var root Vector
+	for i := 1; i <= 30; i++ {
+		// The target is picked from the spine pose of this frame, in reach of the root
+		spine.Resolve(NewVector(0, float64(i)*3), 1.0/60)
+		root, _ = leg.Root()
+		leg.Target = root.Add(NewVector(12, 4))
+		s.ResolveLimbs(1.0 / 60)
+	}
+
+	if got := root.Distance(spine.Joints()[2]); math.Abs(got-5) > 1e-9 {
+		t.Errorf("root is %v from spine joint 2, want 5", got)
+	}
+	if tail := leg.Chain.Joints()[leg.Chain.Len()-1]; tail.Distance(root) > 1e-9 {
+		t.Errorf("leg tail at %v, want it on the root %v", tail, root)
+	}
+	if got := leg.Chain.Head().Distance(leg.Target); got > 1e-6 {
+		t.Errorf("leg head is %v from its target", got)
+	}
+	checkLinks(t, leg.Chain)
+}
@@ -0,0 +1,94 @@
+package kinematics
+
+const (
+	// DefaultIterations is the most passes an iterative solver makes per call
+	DefaultIterations = 10
+
+	// DefaultTolerance is how close the head must get to the target to stop early
+	DefaultTolerance = 0.01
+)
+
+// Solver moves the joints of a chain so its head reaches toward a target
+type Solver interface {
+	// Solve moves c toward target, dt seconds after the previous call
+	Solve(c *Chain, target Vector, dt float64) Result
+}
+
+// Result reports how a solver did, so solvers can be compared on the same rig
+type Result struct {
+	Iterations int     // Passes over the chain that were used
+	Error      float64 // Distance left between the head and the target
+}
+
+// FollowLeader drags the head toward the target, smoothed by the chain
+// half-life, and lets every other joint follow the one before it. Nothing is
+// anchored. This is the default solver.
+type FollowLeader struct{}
+
+// Solve implements Solver
+func (FollowLeader) Solve(c *Chain, target Vector, dt float64) Result {
+	c.followLeader(target, dt)
+	return Result{Iterations: 1, Error: c.joints[0].Distance(target)}
+}
+
+// measureAngles recomputes the link headings from the joint positions
+func (c *Chain) measureAngles() {
+	for i := 1; i < len(c.joints); i++ {
+		c.angles[i] = c.heading(i)
+	}
+}
+
+// heading returns the direction of link i from the joint positions. A link
+// too short to point anywhere, such as one that has just started growing or
+// a joint dragged onto the next, keeps its last heading.
+func (c *Chain) heading(i int) float64 {
+	link := c.joints[i-1].Subtract(c.joints[i])
+	if link.MagnitudeSquared() < 1e-12 {
+		return c.angles[i]
+	}
+
+	return link.Angle()
+}
+
+// layoutFrom pins the tail at anchor and places every other joint from the
+// link headings, so links are exactly their spec length
+func (c *Chain) layoutFrom(anchor Vector) {
+	last := len(c.joints) - 1
+	c.joints[last] = anchor
+	for i := last; i > 0; i-- {
+		c.joints[i-1] = c.joints[i].Add(FromAngle(c.angles[i]).Multiply(c.specs[i].Length))
+	}
+
+	if last > 0 {
+		c.angles[0] = c.angles[1]
+	}
+}
+
+// angleDelta returns the signed turn, in (-pi, pi], that takes from to to
+func angleDelta(from, to float64) float64 {
+	return -RelativeAngleDiff(to, from)
+}
+
+// withDefaults replaces zero iteration and tolerance settings
+func withDefaults(iterations int, tolerance float64) (int, float64) {
+	if iterations <= 0 {
+		iterations = DefaultIterations
+	}
+
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	return iterations, tolerance
+}
+
+// clampTurn limits the turn delta of link i so it stays within the bend
+// range of the link behind it, closer to the tail
+func (c *Chain) clampTurn(i int, delta float64) float64 {
+	if i >= len(c.joints)-1 {
+		return delta
+	}
+
+	heading := c.bendBack(i, c.angles[i]+delta)
+	return angleDelta(c.angles[i], heading)
+}
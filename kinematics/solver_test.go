@@ -0,0 +1,90 @@
+package kinematics
+
+import (
+	"math"
+	"testing"
+)
+
+// anchored returns the anchored solvers, all set up for the same rig
+func anchored(anchor Vector) map[string]Solver {
+	return map[string]Solver{
+		"Fabrik":             Fabrik{Anchor: anchor, Iterations: 50},
+		"CCD":                CCD{Anchor: anchor, Iterations: 50},
+		"DampedLeastSquares": DampedLeastSquares{Anchor: anchor, Iterations: 200},
+	}
+}
+
+// rig returns the arm the solvers are compared on: six joints, 50 apart,
+// bending up to 45 degrees, with its tail on anchor
+func rig(anchor Vector) *Chain {
+	c := NewChain(anchor, 6, 50, math.Pi/4)
+	c.SetHalfLife(0)
+	return c
+}
+
+// checkLinks fails the test when any link is off its spec length
+func checkLinks(t *testing.T, c *Chain) {
+	t.Helper()
+
+	joints := c.Joints()
+	for i := 1; i < len(joints); i++ {
+		if got, want := joints[i].Distance(joints[i-1]), c.Spec(i).Length; math.Abs(got-want) > 1e-6 {
+			t.Errorf("link %d is %v long, want %v", i, got, want)
+		}
+	}
+}
+
+func TestSolversReach(t *testing.T) {
+	anchor := NewVector(0, 0)
+	target := NewVector(120, -150)
+
+	for name, solver := range anchored(anchor) {
+		t.Run(name, func(t *testing.T) {
+			c := rig(anchor)
+			c.SetSolver(solver)
+
+			var result Result
+			for range 10 {
+				result = c.Resolve(target, 1.0/60)
+			}
+
+			if result.Error > DefaultTolerance {
+				t.Errorf("error %v after %d iterations, want at most %v", result.Error, result.Iterations, DefaultTolerance)
+			}
+			if got := c.Head().Distance(target); math.Abs(got-result.Error) > 1e-9 {
+				t.Errorf("reported error %v, head is %v from the target", result.Error, got)
+			}
+
+			tail := c.Joints()[c.Len()-1]
+			if tail.Distance(anchor) > 1e-9 {
+				t.Errorf("tail at %v, want it on the anchor %v", tail, anchor)
+			}
+
+			checkLinks(t, c)
+		})
+	}
+}
+
+func TestSolversOutOfReach(t *testing.T) {
+	anchor := NewVector(0, 0)
+	target := NewVector(1000, 0)
+
+	for name, solver := range anchored(anchor) {
+		t.Run(name, func(t *testing.T) {
+			c := rig(anchor)
+			c.SetSolver(solver)
+
+			result := c.Resolve(target, 1.0/60)
+			if min := target.Distance(anchor) - c.Length(); result.Error < min-1e-9 {
+				t.Errorf("error %v, want at least %v", result.Error, min)
+			}
+
+			tail := c.Joints()[c.Len()-1]
+			if tail.Distance(anchor) > 1e-9 {
+				t.Errorf("tail at %v, want it on the anchor %v", tail, anchor)
+			}
+
+			checkLinks(t, c)
+		})
+	}
+}
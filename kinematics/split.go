@@ -0,0 +1,66 @@
+package kinematics
+
+import "slices"
+
+// Split cuts the chain in front of joint index. The chain keeps the joints
+// before it and the joints from index on are returned as a new chain, with
+// joint index as its head. The new chain takes over the solver, smoothing,
+// colliders and self collision. A Serpenoid keeps state, so the new chain
+// gets its own copy, carrying on the wave its joints were in. Split returns
+// nil and leaves the chain whole when index is not between 1 and Len()-1.
+func (c *Chain) Split(index int) *Chain {
+	if index < 1 || index >= len(c.joints) {
+		return nil
+	}
+
+	tail := &Chain{
+		joints:    slices.Clone(c.joints[index:]),
+		angles:    slices.Clone(c.angles[index:]),
+		specs:     slices.Clone(c.specs[index:]),
+		solver:    c.solver,
+		halfLife:  c.halfLife,
+		colliders: slices.Clone(c.colliders),
+		growth:    slices.Clone(c.growth[index:]),
+		spin:      slices.Clone(c.spin[index:]),
+		motion:    slices.Clone(c.motion[index:]),
+	}
+
+	if wave, ok := c.solver.(*Serpenoid); ok {
+		tail.solver = wave.split(index, len(c.joints))
+	}
+
+	// The cut link becomes the head template, at its full length
+	tail.specs[0] = c.Spec(index)
+	tail.growth[0] = growth{}
+	tail.spin[0] = 0
+
+	if c.self != nil {
+		tail.SetSelfCollision(c.self.gap)
+	}
+
+	c.joints = slices.Delete(c.joints, index, len(c.joints))
+	c.angles = slices.Delete(c.angles, index, len(c.angles))
+	c.specs = slices.Delete(c.specs, index, len(c.specs))
+	c.growth = slices.Delete(c.growth, index, len(c.growth))
+	c.spin = slices.Delete(c.spin, index, len(c.spin))
+	c.motion = slices.Delete(c.motion, index, len(c.motion))
+
+	return tail
+}
+
+// Merge joins tail end to end behind the chain. The head of tail is linked to
+// the last joint by the length and bend range of its head spec, then the
+// joints of tail follow with their own specs. The chain keeps its solver,
+// colliders and self collision; tail is left unchanged and should be dropped.
+func (c *Chain) Merge(tail *Chain) {
+	c.joints = append(c.joints, tail.joints...)
+	c.angles = append(c.angles, tail.angles...)
+	c.specs = append(c.specs, tail.specs...)
+	c.growth = append(c.growth, tail.growth...)
+	c.spin = append(c.spin, tail.spin...)
+	c.motion = append(c.motion, tail.motion...)
+
+	// A recorded path only reaches the old tail, lay the joined body out afresh
+	c.ClearPath()
+	c.Resolve(c.joints[0], 0)
+}
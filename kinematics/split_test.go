@@ -0,0 +1,101 @@
+package kinematics
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// splitRig returns a bent chain whose joints all have different specs
+func splitRig() *Chain {
+	specs := make([]JointSpec, 10)
+	for i := range specs {
+		specs[i] = JointSpec{Length: 10 + float64(i), Min: -0.3 - float64(i)/100, Max: 0.4, Radius: float64(i)}
+	}
+
+	c := NewChainFromSpecs(NewVector(0, 0), specs)
+	for i := 1; i <= 60; i++ {
+		c.Resolve(NewVector(float64(i)*4, 30*math.Sin(float64(i)/8)), 1.0/60)
+	}
+
+	return c
+}
+
+func TestSplit(t *testing.T) {
+	c := splitRig()
+	joints, specs, length := slices.Clone(c.Joints()), slices.Clone(c.specs), c.Length()
+
+	for _, index := range []int{0, c.Len()} {
+		if tail := c.Split(index); tail != nil || c.Len() != len(joints) {
+			t.Errorf("splitting at %d gave %v and left %d joints, want nil and %d", index, tail, c.Len(), len(joints))
+		}
+	}
+
+	tail := c.Split(4)
+	if c.Len() != 4 || tail.Len() != 6 {
+		t.Fatalf("split into %d and %d joints, want 4 and 6", c.Len(), tail.Len())
+	}
+	if !slices.Equal(c.Joints(), joints[:4]) || !slices.Equal(tail.Joints(), joints[4:]) {
+		t.Errorf("joints moved in the split")
+	}
+	if !slices.Equal(c.specs, specs[:4]) || !slices.Equal(tail.specs, specs[4:]) {
+		t.Errorf("specs changed in the split")
+	}
+
+	// Only the cut link is lost
+	if got, want := c.Length()+tail.Length(), length-specs[4].Length; math.Abs(got-want) > 1e-9 {
+		t.Errorf("halves are %v long together, want %v", got, want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	c := splitRig()
+	joints, specs, length := slices.Clone(c.Joints()), slices.Clone(c.specs), c.Length()
+
+	c.Merge(c.Split(4))
+	if c.Len() != len(joints) || !slices.Equal(c.specs, specs) || c.Length() != length {
+		t.Fatalf("merged back into %d joints, %v long; want %d, %v", c.Len(), c.Length(), len(joints), length)
+	}
+	for i, p := range c.Joints() {
+		if p.Distance(joints[i]) > 1e-9 {
+			t.Errorf("joint %d moved from %v to %v", i, joints[i], p)
+		}
+	}
+
+	// A tail from elsewhere is linked on by its head spec
+	c.Merge(NewChain(NewVector(500, 500), 3, 7, math.Pi/8))
+	joints = c.Joints()
+	for i := 1; i < len(joints); i++ {
+		if got, want := joints[i].Distance(joints[i-1]), c.Spec(i).Length; math.Abs(got-want) > 1e-9 {
+			t.Errorf("link %d is %v long, want %v", i, got, want)
+		}
+	}
+}
+
+func TestSplitCopiesSerpenoid(t *testing.T) {
+	c := NewChain(NewVector(0, 0), 12, 20, math.Pi/6)
+	wave := &Serpenoid{Amplitude: 0.3, Frequency: 1, Wavelength: 8}
+	c.SetSolver(wave)
+	c.Resolve(NewVector(100, 0), 1.0/60)
+
+	tail := c.Split(5)
+	copied, ok := tail.Solver().(*Serpenoid)
+	if !ok || copied == wave || c.Solver() != wave {
+		t.Fatalf("tail solver %v, head solver %v; want each its own Serpenoid", tail.Solver(), c.Solver())
+	}
+
+	// The tail's wave is where it was at the cut, 5 joints behind the head
+	if got, want := copied.Phase(), SimplifyAngle(wave.Phase()-TwoPi*5/8); math.Abs(angleDelta(want, got)) > 1e-9 {
+		t.Errorf("tail phase %v, want %v", got, want)
+	}
+
+	// Each wave advances once a frame
+	head, behind := wave.Phase(), copied.Phase()
+	c.Resolve(NewVector(100, 0), 1.0/60)
+	tail.Resolve(NewVector(100, 0), 1.0/60)
+	for name, moved := range map[string]float64{"head": angleDelta(head, wave.Phase()), "tail": angleDelta(behind, copied.Phase())} {
+		if want := TwoPi / 60; math.Abs(moved-want) > 1e-9 {
+			t.Errorf("%s wave moved %v in a frame, want %v", name, moved, want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package kinematics
+
+import "math"
+
+// TwoBoneSpec describes a limb of two bones, like an arm, a leg or a jaw
+type TwoBoneSpec struct {
+	Upper float64 // Length from the root to the middle joint
+	Lower float64 // Length from the middle joint to the end
+
+	// Min and Max are how far the lower bone may turn from the upper one, in
+	// radians with Min <= Max. Both 0 leaves the joint free.
+	Min, Max float64
+
+	// Side is the way the joint bends when both ways reach the target: 1 turns
+	// the lower bone toward larger headings, -1 toward smaller, 0 = 1
+	Side float64
+}
+
+// TwoBonePose is a limb placed by SolveTwoBone
+type TwoBonePose struct {
+	Joint Vector  // Middle joint, the knee or elbow
+	End   Vector  // End of the lower bone, the foot or hand
+	Upper float64 // Heading of the upper bone from the root
+	Lower float64 // Heading of the lower bone from the joint
+	Bend  float64 // Lower minus Upper, within the spec range
+	Error float64 // Distance left between End and the target
+}
+
+// SolveTwoBone reaches the end of a two bone limb rooted at root toward
+// target in one step, by the law of cosines. A target out of reach gets the
+// limb pointing straight at it, or folded as far as the bones allow when it
+// is too close. When the preferred side bends past the joint limits the
+// other side is tried, and failing that the bend stops at whichever limit
+// leaves the end closest to the target, with the limb aimed at it.
+func SolveTwoBone(root, target Vector, spec TwoBoneSpec) TwoBonePose {
+	upper, lower := spec.Upper, spec.Lower
+
+	toTarget := target.Subtract(root)
+	base := 0.0
+	if toTarget.MagnitudeSquared() > 0 {
+		base = toTarget.Angle()
+	}
+
+	// The reach clamp: no closer than the bones fold and no further than they stretch
+	distance := math.Max(math.Abs(upper-lower), math.Min(upper+lower, toTarget.Magnitude()))
+
+	// The joint turns by pi minus the angle inside the triangle at the joint
+	bend := 0.0
+	if upper > 0 && lower > 0 {
+		cos := (upper*upper + lower*lower - distance*distance) / (2 * upper * lower)
+		bend = math.Pi - math.Acos(math.Max(-1, math.Min(1, cos)))
+	}
+	if spec.Side < 0 {
+		bend = -bend
+	}
+
+	if spec.Min != 0 || spec.Max != 0 {
+		inRange := func(b float64) bool { return b >= spec.Min && b <= spec.Max }
+		switch {
+		case inRange(bend):
+		case inRange(-bend):
+			bend = -bend
+		default:
+			// Aimed at the target the end misses by how far its reach is from
+			// the target distance, which is least at one of the limits
+			miss := func(b float64) float64 {
+				return math.Abs(math.Sqrt(upper*upper+lower*lower+2*upper*lower*math.Cos(b)) - toTarget.Magnitude())
+			}
+
+			preferred, other := spec.Max, spec.Min
+			if spec.Side < 0 {
+				preferred, other = other, preferred
+			}
+
+			bend = preferred
+			if miss(other) < miss(preferred) {
+				bend = other
+			}
+		}
+	}
+
+	// Turn the upper bone so the end lands on the line to the target
+	heading := base - math.Atan2(lower*math.Sin(bend), upper+lower*math.Cos(bend))
+
+	pose := TwoBonePose{
+		Upper: angleDelta(0, heading),
+		Lower: angleDelta(0, heading+bend),
+		Bend:  bend,
+	}
+	pose.Joint = root.Add(FromAngle(heading).Multiply(upper))
+	pose.End = pose.Joint.Add(FromAngle(heading + bend).Multiply(lower))
+	pose.Error = pose.End.Distance(target)
+
+	return pose
+}
+
+// TwoBone reaches the head of a three joint chain toward the target with the
+// tail pinned at Anchor, using SolveTwoBone. The middle joint keeps bending
+// the way it already does, within the bend range of the tail spec; a tail
+// spec with no range leaves it free, as in TwoBoneSpec. Chains of any other
+// length fall back to Fabrik.
+type TwoBone struct {
+	Anchor Vector
+}
+
+// Solve implements Solver
+func (t TwoBone) Solve(c *Chain, target Vector, _ float64) Result {
+	if len(c.joints) != 3 {
+		return Fabrik{Anchor: t.Anchor}.Solve(c, target, 0)
+	}
+
+	// The chain runs head to tail, the limb root to end, so bends flip sign
+	side := 1.0
+	if angleDelta(c.angles[2], c.angles[1]) < 0 {
+		side = -1
+	}
+
+	pose := SolveTwoBone(t.Anchor, target, TwoBoneSpec{
+		Upper: c.specs[2].Length,
+		Lower: c.specs[1].Length,
+		Min:   -c.specs[2].Max,
+		Max:   -c.specs[2].Min,
+		Side:  side,
+	})
+
+	c.joints[0], c.joints[1], c.joints[2] = pose.End, pose.Joint, t.Anchor
+	c.angles[1] = pose.Lower
+	c.angles[2] = pose.Upper
+	c.angles[0] = c.angles[1]
+
+	return Result{Iterations: 1, Error: pose.Error}
+}
+
+// TwoBoneResolve runs a TwoBone solve from anchor, whatever solver the chain
+// is configured with. It returns the remaining distance between head and
+// target, like FabrikResolve.
+func (c *Chain) TwoBoneResolve(target, anchor Vector) float64 {
+	return TwoBone{Anchor: anchor}.Solve(c, target, 0).Error
+}
@@ -0,0 +1,103 @@
+package kinematics
+
+import (
+	"math"
+	"testing"
+)
+
+// checkBones fails t unless pose keeps the bones of spec from root
+func checkBones(t *testing.T, root Vector, spec TwoBoneSpec, pose TwoBonePose) {
+	t.Helper()
+
+	if got := pose.Joint.Distance(root); math.Abs(got-spec.Upper) > 1e-9 {
+		t.Errorf("upper bone is %v long, want %v", got, spec.Upper)
+	}
+	if got := pose.End.Distance(pose.Joint); math.Abs(got-spec.Lower) > 1e-9 {
+		t.Errorf("lower bone is %v long, want %v", got, spec.Lower)
+	}
+	if got := angleDelta(pose.Upper, pose.Lower); math.Abs(angleDelta(got, pose.Bend)) > 1e-9 {
+		t.Errorf("bones turn %v, but Bend is %v", got, pose.Bend)
+	}
+}
+
+func TestSolveTwoBoneSide(t *testing.T) {
+	root, target := NewVector(0, 0), NewVector(50, 0)
+
+	for _, side := range []float64{1, 0, -1} {
+		spec := TwoBoneSpec{Upper: 40, Lower: 30, Side: side}
+		pose := SolveTwoBone(root, target, spec)
+		checkBones(t, root, spec, pose)
+
+		if pose.Error > 1e-9 {
+			t.Errorf("side %v: end is %v from the target", side, pose.Error)
+		}
+		if want := math.Copysign(math.Pi/2, side); side == 0 && pose.Bend != math.Pi/2 || side != 0 && math.Abs(pose.Bend-want) > 1e-9 {
+			t.Errorf("side %v: bend %v, want it toward that side", side, pose.Bend)
+		}
+	}
+}
+
+func TestSolveTwoBoneReach(t *testing.T) {
+	root := NewVector(10, 10)
+	spec := TwoBoneSpec{Upper: 40, Lower: 30}
+
+	tests := []struct {
+		name   string
+		target Vector
+		reach  float64
+	}{
+		{"too far", NewVector(10, 210), 70},
+		{"too close", NewVector(15, 10), 10},
+		{"on the root", root, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pose := SolveTwoBone(root, tt.target, spec)
+			checkBones(t, root, spec, pose)
+
+			if got := pose.End.Distance(root); math.Abs(got-tt.reach) > 1e-9 {
+				t.Errorf("end is %v from the root, want %v", got, tt.reach)
+			}
+			if want := math.Abs(tt.target.Distance(root) - tt.reach); math.Abs(pose.Error-want) > 1e-9 {
+				t.Errorf("end is %v from the target, want %v", pose.Error, want)
+			}
+		})
+	}
+}
+
+func TestSolveTwoBoneLimits(t *testing.T) {
+	root, target := NewVector(0, 0), NewVector(50, 0)
+
+	tests := []struct {
+		name     string
+		spec     TwoBoneSpec
+		bend     float64
+		distance float64
+	}{
+		{"preferred side", TwoBoneSpec{Upper: 40, Lower: 30, Min: -2, Max: 2, Side: -1}, -math.Pi / 2, 0},
+		{"other side", TwoBoneSpec{Upper: 40, Lower: 30, Min: -2, Max: -0.1, Side: 1}, -math.Pi / 2, 0},
+		{"closer limit", TwoBoneSpec{Upper: 40, Lower: 30, Min: 0.2, Max: 1, Side: -1}, 1, 11.62},
+		{"too straight", TwoBoneSpec{Upper: 40, Lower: 30, Min: -0.5, Max: 0.3, Side: 1}, -0.5, 17.87},
+		{"too bent", TwoBoneSpec{Upper: 40, Lower: 30, Min: 2, Max: 2.5, Side: 1}, 2, 11.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pose := SolveTwoBone(root, target, tt.spec)
+			checkBones(t, root, tt.spec, pose)
+
+			if math.Abs(pose.Bend-tt.bend) > 1e-9 {
+				t.Errorf("bend %v, want %v", pose.Bend, tt.bend)
+			}
+			if math.Abs(pose.Error-tt.distance) > 0.01 {
+				t.Errorf("end is %v from the target, want about %v", pose.Error, tt.distance)
+			}
+
+			// Aimed at the target, whatever the bend
+			if got := angleDelta(target.Angle(), pose.End.Angle()); math.Abs(got) > 1e-9 {
+				t.Errorf("end is %v off the line to the target", got)
+			}
+		})
+	}
+}
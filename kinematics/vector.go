@@ -1,4 +1,6 @@
-package main
+// Package kinematics provides the 2D vector math and constraint based chains
+// behind the procedural animation demos.
+package kinematics
 
 import (
 	"fmt"
@@ -7,7 +9,8 @@ import (
 
 // Vector represents a 2D vector with X and Y components
 type Vector struct {
-	X, Y float64
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
 }
 
 // NewVector creates a new Vector
@@ -170,41 +173,3 @@ func ConstrainDistanceSymmetric(p1, p2 Vector, minDist, maxDist float64) (Vector
 	// Distance is within bounds
 	return p1, p2
 }
-
-func vectorDemo() {
-	// Example usage
-	fmt.Println("Vector Animation Utilities Demo")
-	fmt.Println("=================================")
-
-	// Create vectors
-	v1 := NewVector(3, 4)
-	v2 := NewVector(1, 2)
-
-	fmt.Printf("v1: %s\n", v1)
-	fmt.Printf("v2: %s\n", v2)
-	fmt.Printf("v1 + v2: %s\n", v1.Add(v2))
-	fmt.Printf("v1 - v2: %s\n", v1.Subtract(v2))
-	fmt.Printf("v1 magnitude: %.2f\n", v1.Magnitude())
-	fmt.Printf("Distance between v1 and v2: %.2f\n", v1.Distance(v2))
-	fmt.Printf("v1 normalized: %s\n", v1.Normalize())
-
-	fmt.Println("\nDistance Constraint Demo:")
-	fmt.Println("========================")
-
-	p1 := NewVector(0, 0)
-	p2 := NewVector(10, 0)
-
-	fmt.Printf("Original points: p1=%s, p2=%s, distance=%.2f\n", p1, p2, p1.Distance(p2))
-
-	// Constrain distance to be between 3 and 6 units
-	constrained := ConstrainDistance(p1, p2, 3, 6)
-	fmt.Printf("Constrained p2: %s, new distance=%.2f\n", constrained, p1.Distance(constrained))
-
-	// Symmetric constraint example
-	p3 := NewVector(-2, 0)
-	p4 := NewVector(2, 0)
-	fmt.Printf("\nSymmetric constraint: p3=%s, p4=%s, distance=%.2f\n", p3, p4, p3.Distance(p4))
-
-	new_p3, new_p4 := ConstrainDistanceSymmetric(p3, p4, 6, 10)
-	fmt.Printf("After symmetric constraint: p3=%s, p4=%s, distance=%.2f\n", new_p3, new_p4, new_p3.Distance(new_p4))
-}
@@ -0,0 +1,137 @@
+package kinematics
+
+const (
+	// DefaultGravity pulls verlet chains down the screen, in pixels per second squared
+	DefaultGravity = 980.0
+)
+
+// VerletChain is a physical chain: every joint keeps its previous position,
+// so it carries momentum, and is pushed around by gravity, drag and
+// impulses. It is a System with a Distance constraint holding every link to
+// its spec length and an Angle constraint holding every bend to its spec
+// range. The bends share the work with the links, so a chain whipped hard can
+// bend past its range for a few frames. Pinned joints do not move on their
+// own, which makes ropes (pin the head), vines (pin the head to a ceiling) and
+// whippy tails (pin the head to a moving body every frame).
+type VerletChain struct {
+	system   *System
+	joints   []Vector // Particle positions after the last step
+	angles   []float64
+	impulses []Vector // Velocity changes collected for the next step
+
+	Gravity    Vector  // Acceleration applied to every joint
+	Drag       float64 // Rate velocity decays at, it keeps exp(-Drag * dt) of itself each step
+	Iterations int     // Constraint relaxation passes per step, 0 = DefaultIterations
+
+	lastDt float64
+}
+
+// NewVerletChain creates a verlet chain at rest on the current pose of c
+func NewVerletChain(c *Chain) *VerletChain {
+	n := len(c.joints)
+	v := &VerletChain{
+		system:   NewSystem(),
+		joints:   append([]Vector(nil), c.joints...),
+		angles:   append([]float64(nil), c.angles...),
+		impulses: make([]Vector, n),
+		Gravity:  NewVector(0, DefaultGravity),
+		Drag:     0.5,
+	}
+
+	for _, p := range c.joints {
+		v.system.AddParticle(p, 1)
+	}
+
+	// Every link first, then every bend, the same order each pass
+	for i := 1; i < n; i++ {
+		length := c.specs[i].Length
+		v.system.Add(&Distance{A: i - 1, B: i, Min: length, Max: length})
+	}
+	for i := 2; i < n; i++ {
+		v.system.Add(&Angle{A: i - 2, B: i - 1, C: i, Min: c.specs[i].Min, Max: c.specs[i].Max})
+	}
+
+	return v
+}
+
+// Joints returns the joint positions, head first. The slice must not be modified.
+func (v *VerletChain) Joints() []Vector {
+	return v.joints
+}
+
+// Angles returns the heading of each joint in radians. The slice must not be modified.
+func (v *VerletChain) Angles() []float64 {
+	return v.angles
+}
+
+// Len returns the number of joints
+func (v *VerletChain) Len() int {
+	return len(v.joints)
+}
+
+// Velocity returns the velocity of joint i over the last step, in pixels per second
+func (v *VerletChain) Velocity(i int) Vector {
+	if v.lastDt == 0 {
+		return Vector{}
+	}
+
+	p := v.system.particles[i]
+	return p.Pos.Subtract(p.Prev).Divide(v.lastDt)
+}
+
+// Pin holds joint i at pos until it is unpinned. Pinning every frame to a
+// moving position drags the rest of the chain along with momentum.
+func (v *VerletChain) Pin(i int, pos Vector) {
+	p := &v.system.particles[i]
+	p.InvMass = 0
+	p.Prev = p.Pos
+	p.Pos = pos
+	v.joints[i] = pos
+}
+
+// Unpin lets joint i move freely again, keeping the velocity of its last Pin move
+func (v *VerletChain) Unpin(i int) {
+	v.system.particles[i].InvMass = 1
+}
+
+// ApplyForce adds an acceleration to joint i for the next step
+func (v *VerletChain) ApplyForce(i int, accel Vector) {
+	p := &v.system.particles[i]
+	p.Force = p.Force.Add(accel)
+}
+
+// ApplyImpulse adds an instant change in velocity to joint i on the next step
+func (v *VerletChain) ApplyImpulse(i int, dv Vector) {
+	v.impulses[i] = v.impulses[i].Add(dv)
+}
+
+// Step integrates dt seconds and then relaxes the constraints
+func (v *VerletChain) Step(dt float64) {
+	v.system.Gravity = v.Gravity
+	v.system.Drag = v.Drag
+	v.system.Iterations = v.Iterations
+
+	if dt > 0 {
+		// An impulse is the force that changes the velocity by dv over one step
+		for i, dv := range v.impulses {
+			v.ApplyForce(i, dv.Divide(dt))
+		}
+		clear(v.impulses)
+
+		v.lastDt = dt
+	}
+
+	v.system.Step(dt)
+	v.joints = v.system.Positions(v.joints[:0])
+	v.measureAngles()
+}
+
+func (v *VerletChain) measureAngles() {
+	for i := 1; i < len(v.joints); i++ {
+		v.angles[i] = v.joints[i-1].Subtract(v.joints[i]).Angle()
+	}
+
+	if len(v.joints) > 1 {
+		v.angles[0] = v.angles[1]
+	}
+}
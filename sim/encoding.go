@@ -0,0 +1,198 @@
+package sim
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"animation/internal/binenc"
+	"animation/kinematics"
+)
+
+const (
+	// EncodingVersion is written into every encoded snake. Decoding accepts
+	// this version and older ones. The chain inside carries its own version.
+	EncodingVersion = 1
+)
+
+// snakeMagic starts the binary encoding of a snake
+const snakeMagic = "snk"
+
+// snakeJSON is the JSON layout of a snake
+type snakeJSON struct {
+	Version       int               `json:"version"`
+	Name          string            `json:"name"`
+	Pos           kinematics.Vector `json:"pos"`
+	Vel           kinematics.Vector `json:"vel"`
+	Color         [4]uint8          `json:"color"`
+	BodyFactor    float64           `json:"bodyFactor"`
+	Radius        float64           `json:"radius"`
+	CollisionTime float64           `json:"collisionTime"`
+	Collision     Collision         `json:"collision"`
+	AteTime       float64           `json:"ateTime"`
+	Gait          *gaitJSON         `json:"gait,omitempty"`
+	Chain         json.RawMessage   `json:"chain"`
+}
+
+// gaitJSON is the JSON layout of a snake's body wave
+type gaitJSON struct {
+	Amplitude  float64 `json:"amplitude"`
+	Frequency  float64 `json:"frequency"`
+	Wavelength float64 `json:"wavelength"`
+	Turn       float64 `json:"turn"`
+	Grip       float64 `json:"grip"`
+	Phase      float64 `json:"phase"`
+}
+
+// MarshalJSON implements json.Marshaler. Both encodings hold everything
+// about the snake, its chain and gait included. Like any decoded chain it
+// comes back without colliders, so give it the world bounds again.
+func (s *Snake) MarshalJSON() ([]byte, error) {
+	v := snakeJSON{
+		Version:       EncodingVersion,
+		Name:          s.Name,
+		Pos:           s.Pos,
+		Vel:           s.Vel,
+		Color:         [4]uint8{s.Color.R, s.Color.G, s.Color.B, s.Color.A},
+		BodyFactor:    s.BodyFactor,
+		Radius:        s.Radius,
+		CollisionTime: s.CollisionTime,
+		Collision:     s.Collision,
+		AteTime:       s.AteTime,
+	}
+
+	chain, err := s.Chain.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	v.Chain = chain
+
+	if g := s.Gait; g != nil {
+		v.Gait = &gaitJSON{
+			Amplitude:  g.Amplitude,
+			Frequency:  g.Frequency,
+			Wavelength: g.Wavelength,
+			Turn:       g.Turn,
+			Grip:       g.Grip,
+			Phase:      g.Phase(),
+		}
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *Snake) UnmarshalJSON(data []byte) error {
+	var v snakeJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v.Version < 1 || v.Version > EncodingVersion {
+		return fmt.Errorf("sim: unsupported snake version %d", v.Version)
+	}
+
+	snake := Snake{
+		Name:          v.Name,
+		Pos:           v.Pos,
+		Vel:           v.Vel,
+		Color:         Color{R: v.Color[0], G: v.Color[1], B: v.Color[2], A: v.Color[3]},
+		BodyFactor:    v.BodyFactor,
+		Radius:        v.Radius,
+		CollisionTime: v.CollisionTime,
+		Collision:     v.Collision,
+		AteTime:       v.AteTime,
+		Chain:         &kinematics.Chain{},
+	}
+
+	if g := v.Gait; g != nil {
+		snake.setGait(&kinematics.Serpenoid{
+			Amplitude:  g.Amplitude,
+			Frequency:  g.Frequency,
+			Wavelength: g.Wavelength,
+			Turn:       g.Turn,
+			Grip:       g.Grip,
+		}, g.Phase)
+	}
+
+	if err := snake.Chain.UnmarshalJSON(v.Chain); err != nil {
+		return err
+	}
+
+	*s = snake
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The snake is little
+// endian like its chain, which takes up the rest of the encoding.
+func (s *Snake) MarshalBinary() ([]byte, error) {
+	b := append([]byte(snakeMagic), EncodingVersion)
+	b = binary.AppendUvarint(b, uint64(len(s.Name)))
+	b = append(b, s.Name...)
+	b = binenc.AppendFloats(b, s.Pos.X, s.Pos.Y, s.Vel.X, s.Vel.Y,
+		s.BodyFactor, s.Radius, s.CollisionTime, s.AteTime)
+	b = append(b, s.Color.R, s.Color.G, s.Color.B, s.Color.A)
+	b = binary.AppendUvarint(b, uint64(s.Collision))
+
+	if g := s.Gait; g != nil {
+		b = append(b, 1)
+		b = binenc.AppendFloats(b, g.Amplitude, g.Frequency, g.Wavelength, g.Turn, g.Grip, g.Phase())
+	} else {
+		b = append(b, 0)
+	}
+
+	return s.Chain.AppendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (s *Snake) UnmarshalBinary(data []byte) error {
+	d := binenc.NewDecoder(data)
+	if magic := d.Bytes(len(snakeMagic)); d.Err() == nil && string(magic) != snakeMagic {
+		return fmt.Errorf("sim: not an encoded snake")
+	}
+
+	if version := d.Byte(); d.Err() == nil && (version < 1 || version > EncodingVersion) {
+		return fmt.Errorf("sim: unsupported snake version %d", version)
+	}
+
+	v := Snake{Name: string(d.Bytes(d.Count(1))), Chain: &kinematics.Chain{}}
+	v.Pos = kinematics.NewVector(d.Float(), d.Float())
+	v.Vel = kinematics.NewVector(d.Float(), d.Float())
+	v.BodyFactor, v.Radius, v.CollisionTime, v.AteTime = d.Float(), d.Float(), d.Float(), d.Float()
+	v.Color = Color{R: d.Byte(), G: d.Byte(), B: d.Byte(), A: d.Byte()}
+	v.Collision = Collision(d.Uvarint())
+
+	var gait *kinematics.Serpenoid
+	var phase float64
+	if d.Byte() != 0 {
+		gait = &kinematics.Serpenoid{
+			Amplitude: d.Float(), Frequency: d.Float(), Wavelength: d.Float(), Turn: d.Float(), Grip: d.Float(),
+		}
+		phase = d.Float()
+	}
+
+	if err := d.Err(); err != nil {
+		return fmt.Errorf("sim: encoded snake is truncated: %w", err)
+	}
+
+	if gait != nil {
+		v.setGait(gait, phase)
+	}
+
+	if err := v.Chain.UnmarshalBinary(d.Rest()); err != nil {
+		return err
+	}
+
+	*s = v
+
+	return nil
+}
+
+// setGait swims s with gait, starting the wave at phase. It must come before
+// the chain is decoded, as changing the solver clears the recorded path.
+func (s *Snake) setGait(gait *kinematics.Serpenoid, phase float64) {
+	gait.SetPhase(phase)
+	s.Gait = gait
+	s.Chain.SetSolver(gait)
+}
@@ -0,0 +1,88 @@
+package sim
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"animation/kinematics"
+)
+
+func TestSnakeRoundTrip(t *testing.T) {
+	w := newTestWorld(4, 3)
+	for range 60 {
+		w.Step(1.0 / 60)
+	}
+
+	restored := newTestWorld(0, 3)
+	for _, s := range w.Snakes() {
+		data, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var back Snake
+		if err := back.UnmarshalBinary(data); err != nil {
+			t.Fatalf("snake %s: decoding: %v", s.Name, err)
+		}
+		if again, _ := back.MarshalBinary(); !bytes.Equal(again, data) {
+			t.Errorf("snake %s: binary changed over a round trip", s.Name)
+		}
+
+		text, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var fromText Snake
+		if err := json.Unmarshal(text, &fromText); err != nil {
+			t.Fatalf("snake %s: decoding JSON: %v", s.Name, err)
+		}
+		if again, _ := fromText.MarshalBinary(); !bytes.Equal(again, data) {
+			t.Errorf("snake %s: JSON round trip does not match the binary encoding", s.Name)
+		}
+
+		restored.AddSnake(&back)
+	}
+
+	if got, want := len(restored.Snakes()), len(w.Snakes()); got != want {
+		t.Fatalf("restored %d snakes, want %d", got, want)
+	}
+
+	// Restored snakes swim on and stay inside the world
+	for range 600 {
+		restored.Step(1.0 / 60)
+	}
+	for _, s := range restored.Snakes() {
+		for _, p := range s.Chain.Joints() {
+			if p.X < 0 || p.Y < 0 || p.X > 1600 || p.Y > 1200 {
+				t.Errorf("snake %s has a joint outside the world at %v", s.Name, p)
+				break
+			}
+		}
+	}
+}
+
+func TestSnakeTruncated(t *testing.T) {
+	s := &Snake{
+		Name:  "cut",
+		Chain: kinematics.NewChain(kinematics.NewVector(0, 0), 4, 20, 1),
+		Gait:  &kinematics.Serpenoid{Amplitude: 0.3, Frequency: 1},
+	}
+	s.Chain.SetSolver(s.Gait)
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every cut runs out of data, even one inside the chain
+	for n := 0; n < len(data); n++ {
+		var back Snake
+		if err := back.UnmarshalBinary(data[:n]); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("cut to %d of %d bytes gave %v, want io.ErrUnexpectedEOF", n, len(data), err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package sim
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// chunkSize is how many items a worker takes at a time. Small enough to
+// balance uneven snakes across workers, big enough to keep the counter cold.
+const chunkSize = 16
+
+// parallel calls fn on consecutive chunks of [0, n), spread over up to
+// workers goroutines that take the next chunk whenever they finish one. fn
+// must only touch state belonging to the items it is given, so the outcome
+// does not depend on the number of workers or the order chunks run in.
+func parallel(workers, n int, fn func(from, to int)) {
+	chunks := (n + chunkSize - 1) / chunkSize
+	workers = min(workers, chunks)
+	if workers <= 1 {
+		if n > 0 {
+			fn(0, n)
+		}
+		return
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for {
+				chunk := int(next.Add(1)) - 1
+				if chunk >= chunks {
+					return
+				}
+
+				from := chunk * chunkSize
+				fn(from, min(from+chunkSize, n))
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,863 @@
+// Package sim runs the snake ecosystem. It has no dependency on raylib, so a
+// World can be stepped headless and drawn by a separate layer.
+package sim
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"runtime"
+
+	"animation/creature"
+	"animation/kinematics"
+)
+
+const (
+	MinSpeed   = 100
+	MaxSpeed   = 200
+	NumSnakes  = 7
+	NumFish    = 2
+	NumJellies = 1
+
+	CollisionTime = 1.5
+	HealthCheck   = 5.0
+	Digestion     = 3.0
+	GrowthTime    = 0.25 // Half life of a joint won in a collision growing in
+
+	MinJoints = 6  // Shorter snakes die
+	MaxJoints = 50 // Longer snakes burst
+)
+
+// Collision records what last happened to a snake, so the drawing layer can
+// pick a highlight color without the simulation knowing about raylib.
+type Collision int
+
+const (
+	CollisionNone Collision = iota
+	CollisionAte            // snake ate the food
+	CollisionWon            // snake gained joints in a collision
+	CollisionLost           // snake lost joints in a collision
+)
+
+// Color is an RGBA color, kept free of raylib so the world can run headless
+type Color struct {
+	R, G, B, A uint8
+}
+
+// Snake is a chain whose head follows Pos as it is steered around the world
+type Snake struct {
+	Name          string
+	Pos           kinematics.Vector
+	Vel           kinematics.Vector
+	Chain         *kinematics.Chain
+	Color         Color
+	BodyFactor    float64
+	Radius        float64
+	CollisionTime float64
+	Collision     Collision
+	AteTime       float64
+	Gait          *kinematics.Serpenoid // Body wave that swims the snake, Vel only steers it
+}
+
+// Creature is an animal from package creature, steered through the world by
+// a target it chases
+type Creature interface {
+	Update(target kinematics.Vector, dt float64)
+	Head() kinematics.Vector
+}
+
+// Critter is a creature living alongside the snakes. It wanders and chases
+// food the same way, but never trades joints.
+type Critter struct {
+	Name     string
+	Creature Creature
+	Pos      kinematics.Vector
+	Vel      kinematics.Vector
+	Radius   float64
+	AteTime  float64
+}
+
+// Food is the single piece of food the snakes compete for. It is an amoeba
+// creeping slowly around the world.
+type Food struct {
+	Pos    kinematics.Vector
+	Radius float64
+	Body   *creature.Amoeba
+
+	target kinematics.Vector // Where the amoeba is creeping to
+}
+
+// Bite decides what happens when the head of biter closes on joint of the
+// body of bitten
+type Bite func(w *World, biter, bitten *Snake, joint int)
+
+// World owns the snakes, the food and the simulation clock. It never calls
+// into raylib, so it can be stepped without a window or GPU.
+type World struct {
+	width, height float64
+	snakes        []*Snake
+	critters      []*Critter
+	food          Food
+	time          float64
+	healthTicker  float64
+	rng           *rand.Rand
+	verbose       bool
+	bite          Bite
+	population    int // Snakes created by Reset
+	workers       int // Goroutines sharing the per snake work
+
+	// Scratch for the collision checks, indexed like snakes
+	heads  [][]int     // Later snakes whose heads touch each head
+	bodies []box       // Bounds of each body
+	bites  [][]contact // Joints of other snakes each head touches
+}
+
+// box is an axis aligned bounding box
+type box struct {
+	min, max kinematics.Vector
+}
+
+// contact is a joint of another snake
+type contact struct {
+	snake, joint int
+}
+
+// NewWorld creates a populated world of the given size. The same seed always
+// produces the same simulation.
+func NewWorld(width, height float64, seed uint64) *World {
+	w := &World{
+		width:      width,
+		height:     height,
+		rng:        rand.New(rand.NewPCG(seed, seed)),
+		verbose:    true,
+		bite:       CutTail,
+		population: NumSnakes,
+		workers:    runtime.GOMAXPROCS(0),
+	}
+
+	w.Reset()
+
+	return w
+}
+
+// Reset replaces the snakes, critters and food, leaving the clock running
+func (w *World) Reset() {
+	w.initSnakes()
+	w.initCritters()
+	w.initFood()
+}
+
+// bounds returns the collider keeping chains inside the world
+func (w *World) bounds() kinematics.Collider {
+	return kinematics.Bounds{Max: kinematics.NewVector(w.width, w.height)}
+}
+
+// Time returns the simulation time in seconds
+func (w *World) Time() float64 {
+	return w.time
+}
+
+// Snakes returns the living snakes. The slice must not be modified.
+func (w *World) Snakes() []*Snake {
+	return w.snakes
+}
+
+// Critters returns the creatures living alongside the snakes. The slice must not be modified.
+func (w *World) Critters() []*Critter {
+	return w.critters
+}
+
+// AddCreature puts a creature into the world, with its head at pos and a
+// body radius used to eat food and bounce off the walls
+func (w *World) AddCreature(name string, c Creature, pos kinematics.Vector, radius float64) *Critter {
+	speed := MinSpeed + w.rng.Float64()*(MaxSpeed-MinSpeed)
+	critter := &Critter{
+		Name:     name,
+		Creature: c,
+		Pos:      pos,
+		Vel:      kinematics.FromAngle(w.rng.Float64() * math.Pi * 2).Multiply(speed),
+		Radius:   radius,
+	}
+
+	w.critters = append(w.critters, critter)
+
+	return critter
+}
+
+// AddSnake puts a snake into the world, such as one decoded from a snapshot.
+// Colliders are not encoded, so the chain is given the world bounds again,
+// and its collision radii are fitted to the body.
+func (w *World) AddSnake(s *Snake) {
+	s.Chain.SetColliders(w.bounds())
+	fitBody(s.Chain, s.BodyFactor)
+
+	w.snakes = append(w.snakes, s)
+}
+
+// Food returns the current food
+func (w *World) Food() Food {
+	return w.food
+}
+
+// SetBite changes what happens when a snake bites another mid body. The
+// default is CutTail; nil turns biting off.
+func (w *World) SetBite(bite Bite) {
+	w.bite = bite
+}
+
+// SetPopulation sets how many snakes the next Reset creates
+func (w *World) SetPopulation(snakes int) {
+	w.population = snakes
+}
+
+// SetWorkers sets how many goroutines share the per snake work of a Step.
+// The simulation comes out the same for any count; 1 runs it all on the
+// calling goroutine.
+func (w *World) SetWorkers(workers int) {
+	w.workers = max(workers, 1)
+}
+
+// SetVerbose toggles the event log printed to stdout
+func (w *World) SetVerbose(verbose bool) {
+	w.verbose = verbose
+}
+
+// Step advances the simulation by dt seconds
+func (w *World) Step(dt float64) {
+	w.time += dt
+
+	w.update(dt)
+	w.checkPicnic()
+	w.checkCollisions()
+
+	if w.time-w.healthTicker > HealthCheck {
+		w.healthTicker = w.time
+		w.healthCheck()
+	}
+}
+
+func (w *World) logf(format string, args ...any) {
+	if w.verbose {
+		fmt.Printf(format, args...)
+	}
+}
+
+func (w *World) initFood() {
+	radius := w.rng.Float64()*30 + 10
+	border := 100.0
+	pos := w.randomPoint(border)
+
+	w.food = Food{
+		Pos:    pos,
+		Radius: radius,
+		Body:   creature.NewAmoeba(pos, radius),
+		target: w.randomPoint(border),
+	}
+}
+
+// randomPoint returns a point at least border away from the world edges
+func (w *World) randomPoint(border float64) kinematics.Vector {
+	return kinematics.Vector{
+		X: border + w.rng.Float64()*(w.width-2*border),
+		Y: border + w.rng.Float64()*(w.height-2*border),
+	}
+}
+
+func (w *World) initSnakes() {
+	w.snakes = make([]*Snake, w.population)
+
+	for i := 0; i < w.population; i++ {
+		factor := w.rng.Float64()*0.4 + 0.15
+		radius := BodyWidth(0, factor)
+		speed := MinSpeed + w.rng.Float64()*(MaxSpeed-MinSpeed)
+		angle := w.rng.Float64() * math.Pi * 2
+
+		pos := kinematics.Vector{
+			X: radius + (w.rng.Float64()*w.width - 2*radius),
+			Y: radius + (w.rng.Float64()*w.height - 2*radius),
+		}
+
+		vel := kinematics.FromAngle(angle).Multiply(speed)
+
+		specs := make([]kinematics.JointSpec, w.rng.IntN(18)+12)
+		link := float64(w.rng.IntN(24) + 12)
+		bend := math.Pi / ((w.rng.Float64() * 4) + 4)
+		for j := range specs {
+			specs[j] = kinematics.SymmetricJoint(link, bend)
+		}
+
+		chain := kinematics.NewChainFromPoints(spawnCurve(pos, angle, link*float64(len(specs)-1)), specs)
+		gait := &kinematics.Serpenoid{
+			Amplitude:  0.25 + w.rng.Float64()*0.25,
+			Frequency:  0.6 + w.rng.Float64()*0.8,
+			Wavelength: 8 + w.rng.Float64()*8,
+			Turn:       0.15,
+		}
+		chain.SetSolver(gait)
+		chain.SetColliders(w.bounds())
+		chain.SetSelfCollision(0)
+		fitBody(chain, factor)
+		chain.Resolve(pos, 0)
+		snake := Snake{
+			Name:       fmt.Sprintf("%d", i),
+			Chain:      chain,
+			Pos:        pos,
+			Vel:        vel,
+			Radius:     radius,
+			BodyFactor: factor,
+			Color:      w.randomColor(),
+			Gait:       gait,
+		}
+
+		w.snakes[i] = &snake
+
+	}
+}
+
+// spawnCurve returns a gentle S of the given length trailing behind a head
+// at pos facing heading, so new snakes start out already swimming
+func spawnCurve(pos kinematics.Vector, heading, length float64) []kinematics.Vector {
+	back := kinematics.FromAngle(heading).Multiply(-length / 4)
+	side := kinematics.FromAngle(heading + math.Pi/2).Multiply(length / 10)
+
+	points := make([]kinematics.Vector, 5)
+	for k := range points {
+		points[k] = pos.Add(back.Multiply(float64(k))).Add(side.Multiply(math.Sin(float64(k) * math.Pi / 2)))
+	}
+
+	return kinematics.CatmullRom(points, 8)
+}
+
+func (w *World) initCritters() {
+	w.critters = nil
+
+	for i := 0; i < NumFish; i++ {
+		pos := kinematics.Vector{
+			X: w.rng.Float64() * w.width,
+			Y: w.rng.Float64() * w.height,
+		}
+
+		fish := creature.NewFish(pos, 0.4)
+		w.AddCreature(fmt.Sprintf("fish %d", i), fish, pos, fish.Width(0))
+	}
+
+	for i := 0; i < NumJellies; i++ {
+		pos := kinematics.Vector{
+			X: w.rng.Float64() * w.width,
+			Y: w.rng.Float64() * w.height,
+		}
+
+		jelly := creature.NewJelly(pos, 30)
+		w.AddCreature(fmt.Sprintf("jelly %d", i), jelly, pos, jelly.Radius())
+	}
+}
+
+func (w *World) randomColor() Color {
+	return Color{R: uint8(w.rng.IntN(255)), G: uint8(w.rng.IntN(255)), B: uint8(w.rng.IntN(255)), A: 255}
+}
+
+func (w *World) healthCheck() {
+	w.logf("Health check: %v, %0.1f\n", w.food.Pos, w.food.Radius)
+	for _, s := range w.snakes {
+		s.BodyFactor *= 0.95
+	}
+}
+
+func (w *World) smellsFood(head kinematics.Vector, vel *kinematics.Vector) {
+	// Calculate distance between head and food
+	diff := w.food.Pos.Subtract(head)
+	distance := diff.Magnitude()
+
+	// If within 500 pixels of food, head towards it and speed up
+	if distance < 500 && distance > 0 {
+		// Increase speed by 50% when heading towards food
+		speed := vel.Magnitude() * 1.5
+
+		// Set velocity towards food with increased speed
+		*vel = diff.Divide(distance).Multiply(speed)
+	}
+}
+
+func (w *World) update(dt float64) {
+	// The food creeps from spot to spot
+	if w.food.Pos.Distance(w.food.target) < w.food.Radius {
+		w.food.target = w.randomPoint(100)
+	}
+	w.food.Body.Update(w.food.target, dt)
+	w.food.Pos = w.food.Body.Head()
+
+	// Every snake and critter only moves itself, so they can all move at once
+	parallel(w.workers, len(w.snakes), func(from, to int) {
+		for _, s := range w.snakes[from:to] {
+			w.updateSnake(s, dt)
+		}
+	})
+
+	parallel(w.workers, len(w.critters), func(from, to int) {
+		for _, c := range w.critters[from:to] {
+			w.updateCritter(c, dt)
+		}
+	})
+}
+
+// updateSnake steers s and moves it dt seconds
+func (w *World) updateSnake(s *Snake, dt float64) {
+	t := w.time
+
+	// Check if snake smells food and adjust velocity if needed
+	if t-s.CollisionTime > CollisionTime {
+		w.smellsFood(s.Chain.Head(), &s.Vel)
+	}
+
+	// Check if snake has recently eaten food
+	speedFactor := 1.0
+	if s.AteTime > 0 && t-s.AteTime < HealthCheck {
+		// Reduce speed by 50% if snake has eaten food recently
+		speedFactor = 0.5
+	}
+
+	fitBody(s.Chain, s.BodyFactor)
+	if s.Gait != nil {
+		w.swim(s, speedFactor, dt)
+		return
+	}
+
+	w.move(&s.Pos, &s.Vel, s.Radius, speedFactor*dt)
+	s.Chain.Resolve(s.Pos, dt)
+}
+
+// updateCritter steers c and moves it dt seconds
+func (w *World) updateCritter(c *Critter, dt float64) {
+	t := w.time
+
+	w.smellsFood(c.Creature.Head(), &c.Vel)
+
+	speedFactor := 1.0
+	if c.AteTime > 0 && t-c.AteTime < HealthCheck {
+		speedFactor = 0.5
+	}
+
+	w.move(&c.Pos, &c.Vel, c.Radius, speedFactor*dt)
+	c.Creature.Update(c.Pos, dt)
+}
+
+// swim lets the body wave of s carry it dt seconds, steering along Vel, with
+// the wave running at pace times its frequency. Walls turn Vel around as they
+// bounce a point mass.
+func (w *World) swim(s *Snake, pace, dt float64) {
+	// The bounds collider holds the head one body radius off the wall, turn
+	// around a little before that
+	head := s.Chain.Head()
+	w.move(&head, &s.Vel, s.Chain.Spec(0).Radius+1, 0)
+
+	// Hold the wave back rather than the clock, so growth, springs and the
+	// measured motion still see the whole frame
+	s.Gait.SetPhase(s.Gait.Phase() - kinematics.TwoPi*s.Gait.Frequency*(1-pace)*dt)
+
+	s.Chain.Resolve(head.Add(s.Vel), dt)
+	s.Pos = s.Chain.Head()
+}
+
+// move advances pos by vel over dt seconds, bouncing off the world edges
+func (w *World) move(pos, vel *kinematics.Vector, radius, dt float64) {
+	// Update position
+	*pos = pos.Add(vel.Multiply(dt))
+
+	// Boundary collision detection and response
+	if pos.X-radius <= 0 {
+		pos.X = radius
+		vel.X = math.Abs(vel.X)
+	} else if pos.X+radius >= w.width {
+		pos.X = w.width - radius
+		vel.X = -math.Abs(vel.X)
+	}
+
+	if pos.Y-radius <= 0 {
+		pos.Y = radius
+		vel.Y = math.Abs(vel.Y)
+	} else if pos.Y+radius >= w.height {
+		pos.Y = w.height - radius
+		vel.Y = -math.Abs(vel.Y)
+	}
+
+	vel.X = clamp(vel.X, MinSpeed, MaxSpeed)
+	vel.Y = clamp(vel.Y, MinSpeed, MaxSpeed)
+}
+
+// fitBody keeps the collision radius of every joint in step with the body width
+func fitBody(c *kinematics.Chain, bodyFactor float64) {
+	for i := 0; i < c.Len(); i++ {
+		spec := c.Spec(i)
+		spec.Radius = BodyWidth(i, bodyFactor)
+		c.SetSpec(i, spec)
+	}
+}
+
+func clamp(v, min, max float64) float64 {
+	f := 1.0
+	if v < 0 {
+		v = -v
+		f = -1.0
+	}
+
+	if v < min {
+		return min * f
+	} else if v > max {
+		return max * f
+	}
+
+	return v * f
+}
+
+func (w *World) checkPicnic() {
+	for _, s := range w.snakes {
+		// Calculate distance between centers
+		distance := s.Chain.Head().Distance(w.food.Pos)
+		minDistance := s.Radius + w.food.Radius
+		if distance < minDistance && distance > 0 {
+			s.Collision = CollisionAte
+			s.CollisionTime = w.time
+			s.AteTime = w.time // Set the time when food was eaten
+
+			f := math.Sqrt(w.food.Radius) / 100.0
+
+			s.BodyFactor += f
+			s.Chain.GrowJoint(s.Chain.Len(), GrowthTime)
+
+			w.logf("%s ate food f=%0.2f\n", s.Name, f)
+			w.initFood()
+		}
+	}
+
+	for _, c := range w.critters {
+		distance := c.Creature.Head().Distance(w.food.Pos)
+		if distance < c.Radius+w.food.Radius {
+			c.AteTime = w.time
+
+			w.logf("%s ate food\n", c.Name)
+			w.initFood()
+		}
+	}
+}
+
+func (w *World) checkCollisions() {
+	deleteId := -1
+	for i, s := range w.snakes {
+		n := s.Chain.Len()
+		f := s.BodyFactor
+		if n < MinJoints || n > MaxJoints || f < 0.1 || f > 0.75 {
+			w.logf("Deleting %s, f=%0.2f, joints=%d\n", s.Name, f, n)
+			deleteId = i
+		}
+	}
+
+	if deleteId >= 0 {
+		w.snakes = append(w.snakes[:deleteId], w.snakes[deleteId+1:]...)
+	}
+
+	// Find touching heads on the workers, then resolve them one pair at a
+	// time in snake order so the outcome does not depend on the workers
+	w.findHeads()
+	for i, heads := range w.heads {
+		for _, j := range heads {
+			w.collide(w.snakes[i], w.snakes[j])
+		}
+	}
+
+	if w.bite != nil {
+		w.checkBites()
+	}
+}
+
+// findHeads lists, for every snake, the later snakes whose heads touch its head
+func (w *World) findHeads() {
+	n := len(w.snakes)
+	w.heads = resize(w.heads, n)
+
+	parallel(w.workers, n, func(from, to int) {
+		for i := from; i < to; i++ {
+			s1 := w.snakes[i]
+			w.heads[i] = w.heads[i][:0]
+			for j := i + 1; j < n; j++ {
+				s2 := w.snakes[j]
+				distance := s1.Chain.Head().Distance(s2.Chain.Head())
+				if distance < s1.Radius+s2.Radius && distance > 0 {
+					w.heads[i] = append(w.heads[i], j)
+				}
+			}
+		}
+	})
+}
+
+// collide bounces two snakes whose heads touch and, once both are past their
+// cooldown, trades joints from the slower to the faster
+func (w *World) collide(s1, s2 *Snake) {
+	// An earlier pair may have moved the heads apart
+	diff := s2.Chain.Head().Subtract(s1.Chain.Head())
+	distance := diff.Magnitude()
+	if distance >= s1.Radius+s2.Radius || distance == 0 {
+		return
+	}
+
+	t := w.time
+	if t-s1.CollisionTime > CollisionTime && t-s2.CollisionTime > CollisionTime {
+		s1.CollisionTime = t
+		s2.CollisionTime = t
+		// Vel only steers a swimming snake, the head moves as fast as its body carries it
+		m1 := s1.Chain.Velocity(0).Magnitude()
+		m2 := s2.Chain.Velocity(0).Magnitude()
+		var winner, loser *Snake
+
+		if m1 > m2 {
+			winner = s1
+			loser = s2
+		} else {
+			winner = s2
+			loser = s1
+		}
+
+		// 5% exchange
+		n := int(math.Round(0.05 * float64(winner.Chain.Len())))
+		if n < 1 {
+			n = 1
+		}
+		w.logf("Exchange %d joints between %s (winner) and %s\n", n, winner.Name, loser.Name)
+		for k := 0; k < n; k++ {
+			winner.Chain.GrowJoint(winner.Chain.Len(), GrowthTime)
+			loser.Chain.DeleteJoint()
+		}
+
+		winner.Collision = CollisionWon
+		loser.Collision = CollisionLost
+	}
+
+	resolveCollisionWithMass(s1, s2, diff, distance)
+	settle(s1)
+	settle(s2)
+}
+
+// settle moves the body of s to Pos after a collision pushed it there. A
+// swimming head goes where its gait takes it rather than to Pos, so the whole
+// body is shifted by the push instead.
+func settle(s *Snake) {
+	if s.Gait != nil {
+		s.Chain.Translate(s.Pos.Subtract(s.Chain.Head()))
+	}
+
+	s.Chain.Resolve(s.Pos, 0)
+}
+
+// checkBites calls the bite hook for every head touching the body of another
+// snake. Heads touching heads are handled by the exchange above, so the
+// first two joints cannot be bitten. The workers find the touching joints
+// and the bites are then taken in snake order, at most one per head.
+func (w *World) checkBites() {
+	t := w.time
+	n := len(w.snakes)
+	w.bodies = resize(w.bodies, n)
+	w.bites = resize(w.bites, n)
+
+	parallel(w.workers, n, func(from, to int) {
+		for i := from; i < to; i++ {
+			w.bodies[i] = bodyBounds(w.snakes[i].Chain)
+		}
+	})
+
+	// Snakes in their cooldown now stay in it for the whole merge below
+	parallel(w.workers, n, func(from, to int) {
+		for i := from; i < to; i++ {
+			w.bites[i] = w.bites[i][:0]
+			biter := w.snakes[i]
+			if t-biter.CollisionTime <= CollisionTime {
+				continue
+			}
+
+			head := biter.Chain.Head()
+			for j := 0; j < n; j++ {
+				body := w.bodies[j]
+				if i == j || t-w.snakes[j].CollisionTime <= CollisionTime ||
+					head.X+biter.Radius < body.min.X || head.X-biter.Radius > body.max.X ||
+					head.Y+biter.Radius < body.min.Y || head.Y-biter.Radius > body.max.Y {
+					continue
+				}
+
+				if k := biteAt(biter, w.snakes[j]); k > 0 {
+					w.bites[i] = append(w.bites[i], contact{snake: j, joint: k})
+				}
+			}
+		}
+	})
+
+	// Snakes cut off by a bite join the end of the list and wait a turn
+	for i := 0; i < n; i++ {
+		biter := w.snakes[i]
+		for _, c := range w.bites[i] {
+			bitten := w.snakes[c.snake]
+			if t-biter.CollisionTime <= CollisionTime || t-bitten.CollisionTime <= CollisionTime {
+				continue
+			}
+
+			biter.CollisionTime = t
+			bitten.CollisionTime = t
+			biter.Collision = CollisionWon
+			bitten.Collision = CollisionLost
+
+			w.bite(w, biter, bitten, c.joint)
+		}
+	}
+}
+
+// biteAt returns the first body joint of bitten that the head of biter
+// touches, or 0 for none
+func biteAt(biter, bitten *Snake) int {
+	head := biter.Chain.Head()
+	joints := bitten.Chain.Joints()
+	for k := 2; k < len(joints); k++ {
+		reach := biter.Radius + bitten.Chain.Spec(k).Radius
+		if head.DistanceSquared(joints[k]) < reach*reach {
+			return k
+		}
+	}
+
+	return 0
+}
+
+// bodyBounds returns the box around every joint of c and its radius
+func bodyBounds(c *kinematics.Chain) box {
+	b := box{min: c.Head(), max: c.Head()}
+	for i, p := range c.Joints() {
+		r := c.Spec(i).Radius
+		b.min.X, b.min.Y = math.Min(b.min.X, p.X-r), math.Min(b.min.Y, p.Y-r)
+		b.max.X, b.max.Y = math.Max(b.max.X, p.X+r), math.Max(b.max.Y, p.Y+r)
+	}
+
+	return b
+}
+
+// resize returns s with length n, reusing its memory when it is big enough
+func resize[T any](s []T, n int) []T {
+	if cap(s) < n {
+		s = append(s[:cap(s)], make([]T, n-cap(s))...)
+	}
+
+	return s[:n]
+}
+
+// CutTail is the default bite. It cuts the bitten snake in front of the
+// bitten joint, never leaving it shorter than MinJoints. A tail long enough
+// to live on becomes a snake of its own; a shorter one is swallowed, and the
+// biter grows a joint for every two it ate.
+func CutTail(w *World, biter, bitten *Snake, joint int) {
+	joint = max(joint, MinJoints)
+	if joint >= bitten.Chain.Len() {
+		return
+	}
+
+	if bitten.Chain.Len()-joint >= MinJoints {
+		tail := w.Cut(bitten, joint)
+		w.logf("%s bit %s in two, %s swims off with %d joints\n", biter.Name, bitten.Name, tail.Name, tail.Chain.Len())
+		return
+	}
+
+	eaten := bitten.Chain.Split(joint)
+	w.logf("%s bit %d joints off %s\n", biter.Name, eaten.Len(), bitten.Name)
+	for k := 0; k < (eaten.Len()+1)/2; k++ {
+		biter.Chain.GrowJoint(biter.Chain.Len(), GrowthTime)
+	}
+}
+
+// Cut splits snake s in front of joint and adds the tail to the world as a
+// snake of its own, carrying on the way the tail was heading. It returns the
+// new snake, or nil when joint does not split the body.
+func (w *World) Cut(s *Snake, joint int) *Snake {
+	chain := s.Chain.Split(joint)
+	if chain == nil {
+		return nil
+	}
+
+	tail := &Snake{
+		Name:          fmt.Sprintf("%s.%d", s.Name, joint),
+		Pos:           chain.Head(),
+		Vel:           kinematics.FromAngle(chain.Angles()[0]).Multiply(s.Vel.Magnitude()),
+		Chain:         chain,
+		Color:         s.Color,
+		BodyFactor:    s.BodyFactor,
+		Radius:        BodyWidth(0, s.BodyFactor),
+		CollisionTime: s.CollisionTime,
+		Collision:     CollisionLost,
+	}
+
+	if s.Gait != nil {
+		// Split gave the tail its own copy of the wave
+		tail.Gait, _ = chain.Solver().(*kinematics.Serpenoid)
+	}
+
+	w.snakes = append(w.snakes, tail)
+
+	return tail
+}
+
+func resolveCollisionWithMass(s1, s2 *Snake, diff kinematics.Vector, distance float64) {
+	// Normalize the collision vector
+	nx := diff.X / distance
+	ny := diff.Y / distance
+
+	// Calculate masses based on ball radius (assuming density is constant)
+	// Mass proportional to area: mass = π * r²
+	mass1 := math.Pi * s1.Radius * s1.Radius
+	mass2 := math.Pi * s2.Radius * s2.Radius
+	totalMass := mass1 + mass2
+
+	// Separate the balls to prevent overlap based on mass ratio
+	// Heavier balls move less during separation
+	overlap := s1.Radius + s2.Radius - distance
+	separation1 := overlap * (mass2 / totalMass)
+	separation2 := overlap * (mass1 / totalMass)
+
+	s1.Pos.X -= nx * separation1
+	s1.Pos.Y -= ny * separation1
+	s2.Pos.X += nx * separation2
+	s2.Pos.Y += ny * separation2
+
+	// Calculate relative velocity
+	relVelX := s2.Vel.X - s1.Vel.X
+	relVelY := s2.Vel.Y - s1.Vel.Y
+
+	// Calculate relative velocity along collision normal
+	speed := relVelX*nx + relVelY*ny
+
+	// Do not resolve if velocities are separating
+	if speed > 0 {
+		return
+	}
+
+	// Calculate restitution (bounciness) - perfect elastic collision
+	restitution := 1.0
+
+	// Calculate impulse scalar using proper mass formula
+	impulse := (1.0 + restitution) * speed / (1.0/mass1 + 1.0/mass2)
+
+	// Apply impulse to velocities (inverse mass relationship)
+	impulseX := impulse * nx
+	impulseY := impulse * ny
+
+	s1.Vel.X += impulseX / mass1
+	s1.Vel.Y += impulseY / mass1
+	s2.Vel.Y -= impulseX / mass2
+	s2.Vel.Y -= impulseY / mass2
+}
+
+// BodyWidth returns the body radius at joint i for a snake of the given body factor
+func BodyWidth(i int, bodyFactor float64) float64 {
+	size := 0.0
+	switch i {
+	case 0:
+		size = 74
+	case 1:
+		size = 80
+	default:
+		size = float64(64 - i)
+	}
+
+	return size * bodyFactor
+}
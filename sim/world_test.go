@@ -0,0 +1,216 @@
+package sim
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"slices"
+	"testing"
+
+	"animation/kinematics"
+)
+
+// newTestWorld returns a quiet world with the given number of snakes
+func newTestWorld(snakes int, seed uint64) *World {
+	w := NewWorld(1600, 1200, seed)
+	w.SetVerbose(false)
+	w.SetPopulation(snakes)
+	w.Reset()
+
+	return w
+}
+
+// overlapHeads moves the two snakes to the middle of the world with their
+// heads overlapping
+func overlapHeads(s1, s2 *Snake) {
+	center := kinematics.NewVector(800, 600)
+	for k, s := range []*Snake{s1, s2} {
+		at := center.Add(kinematics.NewVector(float64(k)*s1.Radius, 0))
+		s.Chain.Translate(at.Subtract(s.Chain.Head()))
+		s.Pos = s.Chain.Head()
+	}
+}
+
+func TestCollisionSeparatesSwimmers(t *testing.T) {
+	w := newTestWorld(2, 1)
+	s1, s2 := w.snakes[0], w.snakes[1]
+
+	overlapHeads(s1, s2)
+	w.collide(s1, s2)
+
+	if got, want := s1.Chain.Head().Distance(s2.Chain.Head()), s1.Radius+s2.Radius; got < want-1e-6 {
+		t.Errorf("heads are %v apart after the collision, want at least %v", got, want)
+	}
+	for _, s := range []*Snake{s1, s2} {
+		if s.Chain.Head() != s.Pos {
+			t.Errorf("snake %s head at %v, want it on Pos %v", s.Name, s.Chain.Head(), s.Pos)
+		}
+	}
+}
+
+func TestCollisionWinnerSwimsFaster(t *testing.T) {
+	w := newTestWorld(2, 1)
+	s1, s2 := w.snakes[0], w.snakes[1]
+
+	// s1 swims, s2 holds its wave still but steers the hardest
+	s2.Gait.Frequency = 0
+	for range 60 {
+		for _, s := range []*Snake{s1, s2} {
+			s.Chain.Resolve(s.Chain.Head().Add(s.Vel), 1.0/60)
+		}
+	}
+	s1.Vel = kinematics.NewVector(MinSpeed, 0)
+	s2.Vel = kinematics.NewVector(MaxSpeed, MaxSpeed)
+	s1.CollisionTime, s2.CollisionTime = -2*CollisionTime, -2*CollisionTime
+
+	overlapHeads(s1, s2)
+	len1, len2 := s1.Chain.Len(), s2.Chain.Len()
+	w.collide(s1, s2)
+
+	if s1.Collision != CollisionWon || s2.Collision != CollisionLost {
+		t.Errorf("swimmer %v, still snake %v; want the swimmer to win", s1.Collision, s2.Collision)
+	}
+	if s1.Chain.Len() <= len1 || s2.Chain.Len() >= len2 {
+		t.Errorf("lengths went from %d, %d to %d, %d; want joints to go to the swimmer",
+			len1, len2, s1.Chain.Len(), s2.Chain.Len())
+	}
+}
+
+func TestDigestingSnakeSwimsSlower(t *testing.T) {
+	// advance returns how far the wave of a snake runs in half a second
+	advance := func(digesting bool) float64 {
+		w := newTestWorld(1, 4)
+		s := w.snakes[0]
+		w.time = 1
+		if digesting {
+			s.AteTime = w.time
+		}
+
+		phase := s.Gait.Phase()
+		for range 30 {
+			from := s.Chain.Head()
+			w.updateSnake(s, 1.0/60)
+
+			// The chain sees the whole frame, so its velocity matches the move
+			moved := s.Chain.Head().Subtract(from).Multiply(60)
+			if got := s.Chain.Velocity(0); got.Distance(moved) > 1e-6 {
+				t.Fatalf("head velocity %v, but it moved %v in the frame", got, moved)
+			}
+		}
+
+		return math.Mod(s.Gait.Phase()-phase+2*kinematics.TwoPi, kinematics.TwoPi)
+	}
+
+	hungry, full := advance(false), advance(true)
+	if math.Abs(full-hungry/2) > 1e-9 {
+		t.Errorf("the wave of a digesting snake ran %v, a hungry one %v; want half", full, hungry)
+	}
+}
+
+// longSnake returns a world whose only snake has n joints
+func longSnake(n int) (*World, *Snake) {
+	w := newTestWorld(1, 5)
+	s := w.snakes[0]
+	for s.Chain.Len() < n {
+		s.Chain.AddJoint()
+	}
+	for s.Chain.Len() > n {
+		s.Chain.DeleteJoint()
+	}
+
+	return w, s
+}
+
+func TestCut(t *testing.T) {
+	w, s := longSnake(20)
+	want := slices.Clone(s.Chain.Joints()[8:])
+
+	tail := w.Cut(s, 8)
+	if s.Chain.Len() != 8 || tail.Chain.Len() != 12 || len(w.Snakes()) != 2 {
+		t.Fatalf("cut into %d and %d joints, %d snakes; want 8 and 12, 2 snakes", s.Chain.Len(), tail.Chain.Len(), len(w.Snakes()))
+	}
+	for i, p := range tail.Chain.Joints() {
+		if p != want[i] {
+			t.Errorf("tail joint %d moved from %v to %v", i, want[i], p)
+		}
+	}
+	if tail.Gait == s.Gait || tail.Chain.Solver() != tail.Gait || s.Chain.Solver() != s.Gait {
+		t.Errorf("the tail shares the gait of the snake it was cut from")
+	}
+	if w.Cut(s, 0) != nil || w.Cut(s, s.Chain.Len()) != nil {
+		t.Errorf("a cut outside the body made a snake")
+	}
+
+	// Each wave advances once a frame
+	phases := []float64{s.Gait.Phase(), tail.Gait.Phase()}
+	w.Step(1.0 / 60)
+	for k, snake := range []*Snake{s, tail} {
+		moved := math.Mod(snake.Gait.Phase()-phases[k]+2*kinematics.TwoPi, kinematics.TwoPi)
+		if want := kinematics.TwoPi * snake.Gait.Frequency / 60; math.Abs(moved-want) > 1e-9 {
+			t.Errorf("snake %s wave moved %v in a frame, want %v", snake.Name, moved, want)
+		}
+	}
+}
+
+func TestCutTail(t *testing.T) {
+	biter := &Snake{Chain: kinematics.NewChain(kinematics.NewVector(0, 0), 8, 20, 1)}
+
+	// A long tail swims off
+	w, bitten := longSnake(20)
+	CutTail(w, biter, bitten, 10)
+	if bitten.Chain.Len() != 10 || len(w.Snakes()) != 2 || w.Snakes()[1].Chain.Len() != 10 {
+		t.Errorf("long tail: %d joints left, %d snakes; want 10 and a 10 joint tail", bitten.Chain.Len(), len(w.Snakes()))
+	}
+
+	// A short one is eaten, the biter grows a joint for every two
+	w, bitten = longSnake(10)
+	CutTail(w, biter, bitten, 7)
+	if bitten.Chain.Len() != 7 || biter.Chain.Len() != 10 || len(w.Snakes()) != 1 {
+		t.Errorf("short tail: bitten %d, biter %d joints, %d snakes; want 7, 10 and 1",
+			bitten.Chain.Len(), biter.Chain.Len(), len(w.Snakes()))
+	}
+
+	// The bitten snake is never left shorter than MinJoints
+	CutTail(w, biter, bitten, 2)
+	if bitten.Chain.Len() != MinJoints || biter.Chain.Len() != 11 {
+		t.Errorf("bite near the head: bitten %d, biter %d joints; want %d and 11", bitten.Chain.Len(), biter.Chain.Len(), MinJoints)
+	}
+}
+
+// checksum hashes every joint of every snake, in order
+func checksum(w *World) uint64 {
+	h := fnv.New64a()
+	var b [8]byte
+	for _, s := range w.Snakes() {
+		for _, p := range s.Chain.Joints() {
+			for _, v := range [2]float64{p.X, p.Y} {
+				binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+				h.Write(b[:])
+			}
+		}
+	}
+
+	return h.Sum64()
+}
+
+func TestWorkersAgree(t *testing.T) {
+	snakes, steps := 200, 600
+	if testing.Short() {
+		snakes, steps = 50, 120
+	}
+
+	run := func(workers int) (uint64, int) {
+		w := newTestWorld(snakes, 7)
+		w.SetWorkers(workers)
+		for range steps {
+			w.Step(1.0 / 60)
+		}
+
+		return checksum(w), len(w.Snakes())
+	}
+
+	want, left := run(1)
+	if got, n := run(8); got != want || n != left {
+		t.Errorf("8 workers end with %d snakes, checksum %x; 1 worker with %d, %x", n, got, left, want)
+	}
+}